@@ -0,0 +1,160 @@
+package main
+
+import (
+	"compress/bzip2"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+const (
+	locodeDiffOldFlag     = "old"
+	locodeDiffNewFlag     = "new"
+	locodeDiffMinMoveFlag = "min-move"
+	locodeDiffFormatFlag  = "format"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+const (
+	filenameCountries = "countries.csv"
+	filenameLocodes   = "locodes.csv"
+)
+
+var (
+	locodeDiffOldPath string
+	locodeDiffNewPath string
+	locodeDiffMinMove float64
+	locodeDiffFormat  string
+)
+
+func init() {
+	flag.StringVar(&locodeDiffOldPath, locodeDiffOldFlag, "", "Path to the old database (directory with countries.csv and locodes.csv, bzip2-compressed or not)")
+	flag.StringVar(&locodeDiffNewPath, locodeDiffNewFlag, "", "Path to the new database (directory with countries.csv and locodes.csv, bzip2-compressed or not)")
+	flag.Float64Var(&locodeDiffMinMove, locodeDiffMinMoveFlag, 10, "Minimum distance of reported location moves (km)")
+	flag.StringVar(&locodeDiffFormat, locodeDiffFormatFlag, formatText, "Output format (text or json)")
+}
+
+func main() {
+	flag.Parse()
+
+	if err := validateFlags(); err != nil {
+		log.Fatal(err)
+	}
+
+	oldDB, err := openDB(locodeDiffOldPath)
+	if err != nil {
+		log.Fatal(fmt.Errorf("could not open old database: %w", err))
+	}
+
+	newDB, err := openDB(locodeDiffNewPath)
+	if err != nil {
+		log.Fatal(fmt.Errorf("could not open new database: %w", err))
+	}
+
+	var (
+		entries = make([]locodedb.DiffEntry, 0)
+		totals  = make(map[locodedb.DiffKind]int)
+	)
+	for e := range locodedb.Diff(oldDB, newDB, locodeDiffMinMove) {
+		entries = append(entries, e)
+		totals[e.Kind]++
+	}
+
+	if locodeDiffFormat == formatJSON {
+		err = writeJSON(os.Stdout, entries, totals)
+	} else {
+		err = writeText(os.Stdout, entries, totals)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeJSON(w io.Writer, entries []locodedb.DiffEntry, totals map[locodedb.DiffKind]int) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(struct {
+		Totals  map[locodedb.DiffKind]int `json:"totals"`
+		Changes []locodedb.DiffEntry      `json:"changes"`
+	}{totals, entries})
+}
+
+func writeText(w io.Writer, entries []locodedb.DiffEntry, totals map[locodedb.DiffKind]int) error {
+	for _, e := range entries {
+		if _, err := fmt.Fprintln(w, e); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d added, %d removed, %d renamed, %d continent, %d subdivision, %d moved\n",
+		totals[locodedb.DiffAdded], totals[locodedb.DiffRemoved], totals[locodedb.DiffRenamed],
+		totals[locodedb.DiffContinent], totals[locodedb.DiffSubdivision], totals[locodedb.DiffMoved])
+	return err
+}
+
+// openDB opens the database stored in the directory the same way the
+// generator creates it, files can be compressed with bzip2 (".bz2" extension)
+// unless there are plain ones.
+func openDB(dir string) (*locodedb.DB, error) {
+	countries, err := openFile(filepath.Join(dir, filenameCountries))
+	if err != nil {
+		return nil, err
+	}
+	defer countries.Close()
+
+	locodes, err := openFile(filepath.Join(dir, filenameLocodes))
+	if err != nil {
+		return nil, err
+	}
+	defer locodes.Close()
+
+	return locodedb.Open(countries, locodes)
+}
+
+// openFile opens the plain file if it exists and the compressed one
+// otherwise. Plain file is freshly generated, the compressed one can be stale
+// then.
+func openFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err == nil {
+		return file, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	file, err = os.Open(path + ".bz2")
+	if err != nil {
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{bzip2.NewReader(file), file}, nil
+}
+
+func validateFlags() error {
+	switch {
+	case locodeDiffOldPath == "":
+		return errors.New("path to the old database is required")
+	case locodeDiffNewPath == "":
+		return errors.New("path to the new database is required")
+	case locodeDiffMinMove < 0:
+		return errors.New("minimum move distance must not be negative")
+	case locodeDiffFormat != formatText && locodeDiffFormat != formatJSON:
+		return fmt.Errorf("unknown output format %q", locodeDiffFormat)
+	}
+	return nil
+}
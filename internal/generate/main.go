@@ -3,37 +3,91 @@ package main
 import (
 	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
 	airportsdb "github.com/nspcc-dev/locode-db/internal/parsers/db/airports"
 	continentsdb "github.com/nspcc-dev/locode-db/internal/parsers/db/continents/geojson"
+	timezonesdb "github.com/nspcc-dev/locode-db/internal/parsers/db/timezones/geojson"
+	csvoverride "github.com/nspcc-dev/locode-db/internal/parsers/override/csv"
 	csvlocode "github.com/nspcc-dev/locode-db/internal/parsers/table/csv"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
 )
 
 type namesDB struct {
 	*airportsdb.DB
 	*csvlocode.Table
+
+	// countriesFallback enables OpenFlights country names for countries
+	// missing in ISO 3166 country names table.
+	countriesFallback bool
+}
+
+// CountryName returns country name from ISO 3166 country names table,
+// OpenFlights country table is used as a fallback if enabled.
+func (n *namesDB) CountryName(code string) (string, error) {
+	name, err := n.Table.CountryName(code)
+	if errors.Is(err, locode.ErrCountryNotFound) && n.countriesFallback {
+		return n.DB.CountryName(code)
+	}
+	return name, err
+}
+
+// timeZonesDB tries time zone databases one by one until the time zone is
+// found.
+type timeZonesDB []locode.TimeZonesDB
+
+// TimeZone returns time zone from the first database that knows it.
+func (dbs timeZonesDB) TimeZone(country string, p locodedb.Point) (string, error) {
+	for _, db := range dbs {
+		tz, err := db.TimeZone(country, p)
+		if !errors.Is(err, locode.ErrTimeZoneNotFound) {
+			return tz, err
+		}
+	}
+	return "", locode.ErrTimeZoneNotFound
 }
 
 const (
-	locodeGenerateInputFlag      = "in"
-	locodeGenerateSubDivFlag     = "subdiv"
-	locodeGenerateAirportsFlag   = "airports"
-	locodeGenerateCountriesFlag  = "countries"
-	locodeGenerateContinentsFlag = "continents"
-	locodeGenerateOutputFlag     = "out"
+	locodeGenerateInputFlag        = "in"
+	locodeGenerateSubDivFlag       = "subdiv"
+	locodeGenerateAirportsFlag     = "airports"
+	locodeGenerateCountriesFlag    = "countries"
+	locodeGenerateContinentsFlag   = "continents"
+	locodeGenerateOutputFlag       = "out"
+	locodeGenerateNoRemovedFlag    = "skip-removed"
+	locodeGenerateFallbackFlag     = "countries-fallback"
+	locodeGenerateTimeZonesFlag    = "timezones"
+	locodeGenerateReportFlag       = "report"
+	locodeGenerateMaxSkippedFlag   = "max-skipped"
+	locodeGenerateNoPointFlag      = "skip-no-point"
+	locodeGenerateSubDivNamesFlag  = "subdiv-names"
+	locodeGenerateOverrideFlag     = "override"
+	locodeGenerateCountryNamesFlag = "country-names"
 )
 
 var (
-	locodeGenerateInPaths        []string
-	locodeGenerateSubDivPath     string
-	locodeGenerateAirportsPath   string
-	locodeGenerateCountriesPath  string
-	locodeGenerateContinentsPath string
-	locodeGenerateOutPath        string
+	locodeGenerateInPaths          []string
+	locodeGenerateSubDivPath       string
+	locodeGenerateAirportsPath     string
+	locodeGenerateCountriesPath    string
+	locodeGenerateContinentsPath   string
+	locodeGenerateOutPath          string
+	locodeGenerateNoRemoved        bool
+	locodeGenerateFallback         bool
+	locodeGenerateTimeZonesPath    string
+	locodeGenerateReportPath       string
+	locodeGenerateNoPoint          bool
+	locodeGenerateSubDivNamesPath  string
+	locodeGenerateOverridePath     string
+	locodeGenerateCountryNamesPath string
+	locodeGenerateMaxSkipped       = make(map[locode.SkipReason]int)
 )
 
 func init() {
@@ -46,10 +100,34 @@ func init() {
 		return nil
 	})
 	flag.StringVar(&locodeGenerateSubDivPath, locodeGenerateSubDivFlag, "", "Path to UN/LOCODE subdivision database (CSV)")
+	flag.StringVar(&locodeGenerateSubDivNamesPath, locodeGenerateSubDivNamesFlag, "", "Path to ISO 3166-2 subdivision names (CSV, optional, used for subdivisions with missing or undecodable names)")
+	flag.StringVar(&locodeGenerateOverridePath, locodeGenerateOverrideFlag, "", "Path to local UN/LOCODE overrides (CSV, optional)")
 	flag.StringVar(&locodeGenerateAirportsPath, locodeGenerateAirportsFlag, "", "Path to OpenFlights airport database (CSV)")
 	flag.StringVar(&locodeGenerateCountriesPath, locodeGenerateCountriesFlag, "", "Path to OpenFlights country database (CSV)")
 	flag.StringVar(&locodeGenerateContinentsPath, locodeGenerateContinentsFlag, "", "Path to continent polygons (GeoJSON)")
 	flag.StringVar(&locodeGenerateOutPath, locodeGenerateOutputFlag, "", "Target path for generated database (directory))")
+	flag.BoolVar(&locodeGenerateNoRemoved, locodeGenerateNoRemovedFlag, false, "Skip entries marked for removal (\"X\" change indicator)")
+	flag.StringVar(&locodeGenerateTimeZonesPath, locodeGenerateTimeZonesFlag, "", "Path to time zone polygons (GeoJSON, optional, the nearest airport is used otherwise)")
+	flag.StringVar(&locodeGenerateReportPath, locodeGenerateReportFlag, "", "Target path for the report of skipped records (JSON if the extension is .json, CSV otherwise)")
+	flag.Func(locodeGenerateMaxSkippedFlag, "Maximum number of records skipped for the reason (reason=N), can be repeated", func(s string) error {
+		name, num, ok := strings.Cut(s, "=")
+		if !ok {
+			return errors.New("reason=N format is expected")
+		}
+		reason, err := locode.SkipReasonFromString(name)
+		if err != nil {
+			return err
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid number %q", num)
+		}
+		locodeGenerateMaxSkipped[reason] = n
+		return nil
+	})
+	flag.BoolVar(&locodeGenerateNoPoint, locodeGenerateNoPointFlag, false, "Skip entries without coordinates and matching airport")
+	flag.StringVar(&locodeGenerateCountryNamesPath, locodeGenerateCountryNamesFlag, "", "Path to ISO 3166 country names (CSV, optional, OpenFlights names are used otherwise)")
+	flag.BoolVar(&locodeGenerateFallback, locodeGenerateFallbackFlag, false, "Use OpenFlights country names for countries missing in ISO 3166 country names")
 }
 
 func main() {
@@ -65,6 +143,8 @@ func main() {
 			SubDivPath: locodeGenerateSubDivPath,
 		},
 		csvlocode.WithExtraPaths(locodeGenerateInPaths[1:]...),
+		csvlocode.WithSubDivNames(locodeGenerateSubDivNamesPath),
+		csvlocode.WithCountryNames(locodeGenerateCountryNamesPath),
 	)
 
 	airportDB := airportsdb.New(airportsdb.Prm{
@@ -79,14 +159,83 @@ func main() {
 	targetDB := locode.New(locodeGenerateOutPath)
 
 	names := &namesDB{
-		DB:    airportDB,
-		Table: locodeDB,
+		DB:                airportDB,
+		Table:             locodeDB,
+		countriesFallback: locodeGenerateFallback || locodeGenerateCountryNamesPath == "",
+	}
+
+	timeZones := timeZonesDB{airportDB}
+	if locodeGenerateTimeZonesPath != "" {
+		timeZones = append(timeZonesDB{timezonesdb.New(timezonesdb.Prm{
+			Path: locodeGenerateTimeZonesPath,
+		})}, timeZones...)
 	}
 
-	err := locode.FillDatabase(locodeDB, airportDB, continentsDB, names, targetDB)
+	report := new(locode.Report)
+
+	fillOpts := []locode.Option{
+		locode.WithTimeZones(timeZones),
+		locode.WithReport(report),
+	}
+	if locodeGenerateNoRemoved {
+		fillOpts = append(fillOpts, locode.WithoutRemoved())
+	}
+	if locodeGenerateNoPoint {
+		fillOpts = append(fillOpts, locode.WithoutMissingPoints())
+	}
+	if len(locodeGenerateMaxSkipped) != 0 {
+		fillOpts = append(fillOpts, locode.WithMaxSkipped(locodeGenerateMaxSkipped))
+	}
+
+	var overrideDB *csvoverride.DB
+	if locodeGenerateOverridePath != "" {
+		overrideDB = csvoverride.New(csvoverride.Prm{
+			Path: locodeGenerateOverridePath,
+		})
+		fillOpts = append(fillOpts, locode.WithOverrides(overrideDB))
+	}
+
+	// The report is written even if the database is not because of skipped
+	// records to show what's wrong with the tables.
+	fillErr := locode.FillDatabase(locodeDB, airportDB, continentsDB, names, targetDB, fillOpts...)
+	if fillErr != nil && !errors.Is(fillErr, locode.ErrTooManySkipped) {
+		log.Fatal(fillErr)
+	}
+
+	if locodeGenerateReportPath != "" {
+		if err := writeReport(report, locodeGenerateReportPath); err != nil {
+			log.Fatal(fmt.Errorf("could not write report: %w", err))
+		}
+	}
+
+	if fillErr != nil {
+		log.Fatal(fillErr)
+	}
+
+	if overrideDB != nil {
+		for _, o := range overrideDB.Stale() {
+			log.Printf("stale override, it doesn't change anything: %s", o)
+		}
+	}
+}
+
+func writeReport(report *locode.Report, path string) error {
+	file, err := os.Create(path)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
+
+	if filepath.Ext(path) == ".json" {
+		err = report.WriteJSON(file)
+	} else {
+		err = report.WriteCSV(file)
+	}
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
 }
 
 func validateFlags() error {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strconv"
 
@@ -26,7 +27,7 @@ const (
 	_ // Altitude
 	_ // Timezone
 	_ // DST
-	_ // Tz database time zone
+	airportTimeZone
 	_ // Type
 	_ // Source
 
@@ -37,15 +38,26 @@ type record struct {
 	city,
 	country,
 	iata,
-	lat,
-	lng string
+	tz string
+
+	point locodedb.Point
 }
 
+// accuracy is the accuracy radius in kilometers of the location point taken
+// from the airport. Airports are often far from the city they serve, so it
+// doesn't depend on the precision of the airport coordinates.
+const accuracy = 30
+
+// noValue is used by OpenFlights for unknown values.
+const noValue = `\N`
+
 // Get scans the records of the OpenFlights Airport to an in-memory table (once),
 // and returns an entry that matches the passed UN/LOCODE record.
 //
 // Records are matched if they have the same country code and either
-// same IATA code or same city name (location name in UN/LOCODE).
+// same IATA code or same city name (location name in UN/LOCODE). IATA code
+// of the UN/LOCODE record is taken from the IATA column, location code is
+// used if it's empty.
 //
 // Returns locodedb.ErrAirportNotFound if no entry matches.
 func (db *DB) Get(locodeRecord locode.Record) (*locode.AirportRecord, error) {
@@ -55,31 +67,62 @@ func (db *DB) Get(locodeRecord locode.Record) (*locode.AirportRecord, error) {
 
 	records := db.mAirports[locodeRecord.LOCODE[0]]
 
+	iata := locodeRecord.IATA
+	if iata == "" {
+		iata = locodeRecord.LOCODE[1]
+	}
+
 	for i := range records {
-		if locodeRecord.LOCODE[1] != records[i].iata &&
+		if iata != records[i].iata &&
 			locodeRecord.NameWoDiacritics != records[i].city {
 			continue
 		}
 
-		lat, err := strconv.ParseFloat(records[i].lat, 64)
-		if err != nil {
-			return nil, err
-		}
-
-		lng, err := strconv.ParseFloat(records[i].lng, 64)
-		if err != nil {
-			return nil, err
-		}
-
 		return &locode.AirportRecord{
 			CountryName: records[i].country,
-			Point:       locodedb.Point{Latitude: float32(lat), Longitude: float32(lng)},
+			Point:       records[i].point,
+			Accuracy:    accuracy,
 		}, nil
 	}
 
 	return nil, locode.ErrAirportNotFound
 }
 
+// TimeZone scans the records of the OpenFlights Airport to an in-memory table
+// (once), and returns IANA time zone of the airport nearest to the point in
+// the given country.
+//
+// Returns locodedb.ErrTimeZoneNotFound if there are no airports with known
+// time zone in the country.
+func (db *DB) TimeZone(countryCode string, point locodedb.Point) (string, error) {
+	if err := db.initAirports(); err != nil {
+		return "", err
+	}
+
+	var (
+		tz     string
+		minDst = math.Inf(1)
+	)
+
+	for _, rec := range db.mAirports[countryCode] {
+		if rec.tz == "" || rec.tz == noValue {
+			continue
+		}
+
+		dst := point.Distance(rec.point)
+		if dst < minDst {
+			minDst = dst
+			tz = rec.tz
+		}
+	}
+
+	if tz == "" {
+		return "", locode.ErrTimeZoneNotFound
+	}
+
+	return tz, nil
+}
+
 const (
 	_ = iota - 1
 
@@ -99,14 +142,8 @@ func (db *DB) CountryName(code string) (name string, err error) {
 		return
 	}
 
-	for cName, cCode := range db.mCountries {
-		if cCode == code {
-			name = cName
-			break
-		}
-	}
-
-	if name == "" {
+	name, ok := db.mCountryNames[code]
+	if !ok {
 		err = locode.ErrCountryNotFound
 	}
 
@@ -123,16 +160,28 @@ func (db *DB) initAirports() (err error) {
 
 		err = db.scanWords(db.airports, airportFldNum, func(words []string) error {
 			countryCode := db.mCountries[words[airportCountry]]
-			if countryCode != "" {
-				db.mAirports[countryCode] = append(db.mAirports[countryCode], record{
-					city:    words[airportCity],
-					country: words[airportCountry],
-					iata:    words[airportIATA],
-					lat:     words[airportLatitude],
-					lng:     words[airportLongitude],
-				})
+			if countryCode == "" {
+				return nil
+			}
+
+			lat, err := strconv.ParseFloat(words[airportLatitude], 64)
+			if err != nil {
+				return fmt.Errorf("airport latitude: %w", err)
 			}
 
+			lng, err := strconv.ParseFloat(words[airportLongitude], 64)
+			if err != nil {
+				return fmt.Errorf("airport longitude: %w", err)
+			}
+
+			db.mAirports[countryCode] = append(db.mAirports[countryCode], record{
+				city:    words[airportCity],
+				country: words[airportCountry],
+				iata:    words[airportIATA],
+				tz:      words[airportTimeZone],
+				point:   locodedb.Point{Latitude: float32(lat), Longitude: float32(lng)},
+			})
+
 			return nil
 		})
 	})
@@ -143,9 +192,13 @@ func (db *DB) initAirports() (err error) {
 func (db *DB) initCountries() (err error) {
 	db.countriesOnce.Do(func() {
 		db.mCountries = make(map[string]string)
+		db.mCountryNames = make(map[string]string)
 
 		err = db.scanWords(db.countries, countryFldNum, func(words []string) error {
 			db.mCountries[words[countryName]] = words[countryISOCode]
+			if _, ok := db.mCountryNames[words[countryISOCode]]; !ok && words[countryISOCode] != "" {
+				db.mCountryNames[words[countryISOCode]] = words[countryName]
+			}
 
 			return nil
 		})
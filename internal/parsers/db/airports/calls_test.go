@@ -0,0 +1,131 @@
+package airportsdb
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+// Rows are taken from OpenFlights as is.
+const (
+	testAirports = `2985,"Sheremetyevo International Airport","Moscow","Russia","SVO","UUEE",55.972599,37.4146,622,3,"N","Europe/Moscow","airport","OurAirports"
+2948,"Pulkovo Airport","St. Petersburg","Russia","LED","ULLI",59.80030059814453,30.262500762939453,78,3,"N","Europe/Moscow","airport","OurAirports"
+5643,"Kirovsk-Apatity Airport","Apatity","Russia","KVK","ULMK",67.46330261230469,33.58829879760742,515,\N,\N,\N,"airport","OurAirports"
+2958,"Tolmachevo Airport","Novosibirsk","Russia","OVB","UNNT",55.012599945068,82.650703430176,365,7,"N","Asia/Novosibirsk","airport","OurAirports"
+2990,"Kazan International Airport","Kazan","Russia","KZN","UWKD",55.606201171875,49.278701782227,411,3,"N","Europe/Moscow","airport","OurAirports"
+737,"Stockholm-Arlanda Airport","Stockholm","Sweden","ARN","ESSA",59.651901245117,17.918600082397,137,1,"E","Europe/Stockholm","airport","OurAirports"
+`
+	testCountries = `"Russia","RU","RS"
+"Sweden","SE","SW"
+"Norway","NO","NO"
+`
+)
+
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	dir := t.TempDir()
+	prm := Prm{
+		AirportsPath:  filepath.Join(dir, "airports.dat"),
+		CountriesPath: filepath.Join(dir, "countries.dat"),
+	}
+	if err := os.WriteFile(prm.AirportsPath, []byte(testAirports), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(prm.CountriesPath, []byte(testCountries), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return New(prm)
+}
+
+func TestGet(t *testing.T) {
+	db := newTestDB(t)
+
+	testCases := []struct {
+		name    string
+		record  locode.Record
+		want    locodedb.Point
+		wantErr error
+	}{
+		{
+			name:   "IATA",
+			record: locode.Record{LOCODE: [2]string{"RU", "MOW"}, NameWoDiacritics: "Moskva", IATA: "SVO"},
+			want:   locodedb.Point{Latitude: 55.972599, Longitude: 37.4146},
+		},
+		{
+			name:   "location code",
+			record: locode.Record{LOCODE: [2]string{"RU", "LED"}, NameWoDiacritics: "Sankt-Peterburg"},
+			want:   locodedb.Point{Latitude: 59.80030059814453, Longitude: 30.262500762939453},
+		},
+		{
+			name:   "city",
+			record: locode.Record{LOCODE: [2]string{"SE", "STO"}, NameWoDiacritics: "Stockholm"},
+			want:   locodedb.Point{Latitude: 59.651901245117, Longitude: 17.918600082397},
+		},
+		{
+			name:    "other country",
+			record:  locode.Record{LOCODE: [2]string{"NO", "ARN"}, NameWoDiacritics: "Arendal"},
+			wantErr: locode.ErrAirportNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := db.Get(tc.record)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Point != tc.want {
+				t.Errorf("got point %v, want %v", got.Point, tc.want)
+			}
+			// Precision of OpenFlights coordinates doesn't make the point
+			// more accurate.
+			if got.Accuracy != accuracy {
+				t.Errorf("got accuracy %v, want %v", got.Accuracy, float64(accuracy))
+			}
+		})
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	db := newTestDB(t)
+
+	testCases := []struct {
+		name    string
+		country string
+		point   locodedb.Point
+		want    string
+		wantErr error
+	}{
+		{name: "nearest", country: "RU", point: locodedb.Point{Latitude: 55.75, Longitude: 37.62}, want: "Europe/Moscow"},
+		{name: "other nearest", country: "RU", point: locodedb.Point{Latitude: 55.03, Longitude: 82.92}, want: "Asia/Novosibirsk"},
+		// Kirovsk-Apatity airport is the nearest one, but its time zone is
+		// not known.
+		{name: "unknown time zone", country: "RU", point: locodedb.Point{Latitude: 67.61, Longitude: 33.67}, want: "Europe/Moscow"},
+		// Oslo is closer to Stockholm-Arlanda than to any Russian airport,
+		// but only the airports of the country are used.
+		{name: "other country", country: "RU", point: locodedb.Point{Latitude: 59.91, Longitude: 10.75}, want: "Europe/Moscow"},
+		{name: "country", country: "SE", point: locodedb.Point{Latitude: 59.91, Longitude: 10.75}, want: "Europe/Stockholm"},
+		{name: "no airports", country: "NO", point: locodedb.Point{Latitude: 59.91, Longitude: 10.75}, wantErr: locode.ErrTimeZoneNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := db.TimeZone(tc.country, tc.point)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got time zone %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
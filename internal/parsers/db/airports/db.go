@@ -34,8 +34,12 @@ type DB struct {
 
 	airportsOnce, countriesOnce sync.Once
 
+	// mCountries maps country names to codes.
 	mCountries map[string]string
 
+	// mCountryNames maps country codes to names.
+	mCountryNames map[string]string
+
 	mAirports map[string][]record
 }
 
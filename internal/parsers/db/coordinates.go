@@ -2,6 +2,7 @@ package locodedb
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -18,6 +19,15 @@ const (
 	lngDegDigits = 3
 )
 
+const (
+	// minuteAccuracy is the accuracy of degree-minute coordinates in
+	// kilometers (1 arc minute of latitude).
+	minuteAccuracy = 1.852
+
+	// degreeAccuracy is the length of 1 degree of latitude in kilometers.
+	degreeAccuracy = 111.32
+)
+
 type coordinateCode struct {
 	degDigits int
 	value     []uint8
@@ -272,3 +282,25 @@ func minutesToDegrees(raw []byte) (float64, error) {
 
 	return minutes / 60, nil
 }
+
+// Accuracy returns the accuracy radius of the coordinates in kilometers, it
+// depends on the format: degree-minute or decimal degrees with some number of
+// digits after the point. The worst of latitude and longitude is used.
+func (c *Coordinates) Accuracy() float64 {
+	return max((*coordinateCode)(c.lat).accuracy(), (*coordinateCode)(c.lng).accuracy())
+}
+
+func (cc *coordinateCode) accuracy() float64 {
+	s := string(cc.value[:len(cc.value)-hemisphereSymbols])
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return decimalAccuracy(len(s) - i - 1)
+	}
+
+	return minuteAccuracy
+}
+
+// decimalAccuracy returns the accuracy radius in kilometers of the coordinate
+// in decimal degrees with the given number of digits after the point.
+func decimalAccuracy(digits int) float64 {
+	return degreeAccuracy * math.Pow10(-digits)
+}
@@ -0,0 +1,42 @@
+package locodedb
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+const (
+	dateDigits = 4
+
+	// datePivot is the first 2-digit year of 20th century, UN/LOCODE
+	// started in 1980s, so earlier years belong to 21st century.
+	datePivot = 70
+)
+
+// DateFromString parses UN/LOCODE date of the last change in YYMM format
+// (like "1707") and returns the first day of the month.
+func DateFromString(s string) (time.Time, error) {
+	if len(s) != dateDigits {
+		return time.Time{}, locodedb.ErrInvalidString
+	}
+	for i := range s {
+		if !isDigit(s[i]) {
+			return time.Time{}, locodedb.ErrInvalidString
+		}
+	}
+
+	yy, _ := strconv.Atoi(s[:2])
+	mm, _ := strconv.Atoi(s[2:])
+	if mm < 1 || mm > 12 {
+		return time.Time{}, locodedb.ErrInvalidString
+	}
+
+	year := 2000 + yy
+	if yy >= datePivot {
+		year = 1900 + yy
+	}
+
+	return time.Date(year, time.Month(mm), 1, 0, 0, 0, 0, time.UTC), nil
+}
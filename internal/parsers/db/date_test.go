@@ -0,0 +1,78 @@
+package locodedb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateFromString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dateGot  string
+		dateWant time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "Valid date, 21st century",
+			dateGot:  "1707",
+			dateWant: time.Date(2017, time.July, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Valid date, 20th century",
+			dateGot:  "9512",
+			dateWant: time.Date(1995, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Valid date, first year of 20th century",
+			dateGot:  "7001",
+			dateWant: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Valid date, year 2000",
+			dateGot:  "0001",
+			dateWant: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Invalid date, empty",
+			dateGot: "",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date, too short",
+			dateGot: "170",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date, too long",
+			dateGot: "17070",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date, zero month",
+			dateGot: "1700",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date, month overflow",
+			dateGot: "1713",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date, not a number",
+			dateGot: "17A7",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			date, err := DateFromString(test.dateGot)
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !date.Equal(test.dateWant) {
+				t.Errorf("got = %v, want %v", date, test.dateWant)
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ package locodedb
 import (
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 
 	"github.com/nspcc-dev/locode-db/pkg/locodedb"
 )
@@ -23,6 +26,9 @@ type AirportRecord struct {
 
 	// Geo point where airport is located.
 	Point locodedb.Point
+
+	// Accuracy radius of the Point in kilometers.
+	Accuracy float64
 }
 
 // ErrAirportNotFound is returned by AirportRecord readers
@@ -44,6 +50,39 @@ type ContinentsDB interface {
 	PointContinent(locodedb.Point) (*locodedb.Continent, error)
 }
 
+// ErrTimeZoneNotFound is returned by TimeZonesDB when the time zone of the
+// point can't be determined.
+var ErrTimeZoneNotFound = errors.New("time zone not found")
+
+// TimeZonesDB is an interface of time zone database.
+type TimeZonesDB interface {
+	// TimeZone must return IANA time zone name (like "Europe/Moscow") of
+	// the geo point in the country with the given code.
+	//
+	// Must return ErrTimeZoneNotFound if the time zone can't be determined.
+	TimeZone(string, locodedb.Point) (string, error)
+}
+
+// OverrideDB is an interface of local corrections of the UN/LOCODE table.
+type OverrideDB interface {
+	// Patch must apply the overrides to the UN/LOCODE table record.
+	//
+	// Must return false if the record is deleted.
+	Patch(*Record) (bool, error)
+
+	// PatchContinent must return the continent of the location with the
+	// given UN/LOCODE, the continent calculated from coordinates (or
+	// ContinentUnknown if there are none) is passed.
+	PatchContinent([2]string, locodedb.Continent) (locodedb.Continent, error)
+
+	// IterateAdded must iterate over the records missing in the UN/LOCODE
+	// table and pass them to the handler. It's called after all table
+	// records are patched.
+	//
+	// Must return handler's errors directly.
+	IterateAdded(func(Record) error) error
+}
+
 var ErrSubDivNotFound = errors.New("subdivision not found")
 
 var ErrCountryNotFound = errors.New("country not found")
@@ -57,107 +96,315 @@ type NamesDB interface {
 	CountryName(string) (string, error)
 
 	// SubDivName must resolve (country code, subdivision code) to
-	// a subdivision name.
+	// a subdivision name, empty if it's not known.
 	//
 	// Must return ErrSubDivNotFound if either country or
 	// subdivision is not presented in database.
 	SubDivName(string, string) (string, error)
+
+	// SubDivType must resolve (country code, subdivision code) to
+	// a subdivision type (like "Region" or "State").
+	//
+	// Must return ErrSubDivNotFound if either country or
+	// subdivision is not presented in database.
+	SubDivType(string, string) (string, error)
 }
 
 // FillDatabase generates the location database based on the UN/LOCODE table.
-func FillDatabase(table SourceTable, airports AirportDB, continents ContinentsDB, names NamesDB, db CsvDB) error {
-	var newData []Data
-	if err := table.IterateAll(func(tableRecord Record) error {
-		if tableRecord.LOCODE[1] == "" {
-			return nil
-		}
+//
+// Reference entries ("=" change indicator) are attached as aliases to the
+// locations of the same country with the official name. Entries marked for
+// removal are flagged unless WithoutRemoved option is used. Overrides are
+// applied if WithOverrides option is used.
+func FillDatabase(table SourceTable, airports AirportDB, continents ContinentsDB, names NamesDB, db CsvDB, opts ...Option) error {
+	o := defaultOpts()
+
+	for i := range opts {
+		opts[i](o)
+	}
 
-		dbKey, err := NewKey(tableRecord.LOCODE[0], tableRecord.LOCODE[1])
-		if err != nil {
-			return err
+	if o.maxSkipped != nil && o.report == nil {
+		o.report = new(Report)
+	}
+
+	var (
+		newData []Data
+		// aliases maps country code and official location name to the
+		// alternative names.
+		aliases = make(map[[2]string][]string)
+	)
+
+	// fill adds the location database record for the UN/LOCODE table
+	// record, pointOverridden is set if coordinates are overridden.
+	fill := func(dbKey *Key, tableRecord Record, pointOverridden bool) error {
+		if tableRecord.Change == ChangeRemoved && o.skipRemoved {
+			o.report.add(dbKey, SkipRemoved)
+			return nil
 		}
 
+		// Malformed coordinates are treated as missing, but the airport
+		// isn't looked up for them.
 		crd, err := CoordinatesFromString(tableRecord.Coordinates)
-		if err != nil {
-			if errors.Is(err, locodedb.ErrInvalidString) {
-				return nil
-			}
-
+		invalidCoordinates := errors.Is(err, locodedb.ErrInvalidString)
+		if err != nil && !invalidCoordinates {
 			return err
 		}
+		if invalidCoordinates && o.skipNoPoint {
+			o.report.add(dbKey, SkipInvalidCoordinates)
+			return nil
+		}
 
 		geoPoint, err := PointFromCoordinates(crd)
 		if err != nil {
 			return fmt.Errorf("could not parse geo point: %w", err)
 		}
 
-		countryName := ""
+		// warnings are reported only if the record is kept.
+		var warnings []WarnReason
 
-		if geoPoint == (locodedb.Point{}) {
-			airportRecord, err := airports.Get(tableRecord)
-			if err != nil {
-				if errors.Is(err, ErrAirportNotFound) {
-					return nil
-				}
+		if tableRecord.IATA != "" && !IsIATA(tableRecord.IATA) {
+			warnings = append(warnings, WarnInvalidIATA)
+			tableRecord.IATA = ""
+		}
 
-				return err
+		// airportCountryName is used only if the country is not known to
+		// names, so that all records of the country have the same name.
+		airportCountryName := ""
+
+		pointSource := locodedb.PointSourceUNLOCODE
+		var accuracy float64
+		if crd != nil {
+			accuracy = crd.Accuracy()
+			if pointOverridden {
+				pointSource = locodedb.PointSourceOverride
 			}
+		}
 
-			geoPoint = airportRecord.Point
-			countryName = airportRecord.CountryName
+		switch {
+		case invalidCoordinates:
+			pointSource = locodedb.PointSourceNone
+		case geoPoint == (locodedb.Point{}):
+			airportRecord, err := airports.Get(tableRecord)
+			switch {
+			case err == nil:
+				geoPoint = airportRecord.Point
+				airportCountryName = airportRecord.CountryName
+				pointSource = locodedb.PointSourceAirport
+				accuracy = airportRecord.Accuracy
+			case !errors.Is(err, ErrAirportNotFound):
+				return err
+			case o.skipNoPoint:
+				o.report.add(dbKey, SkipNoAirport)
+				return nil
+			default:
+				pointSource = locodedb.PointSourceNone
+				accuracy = 0
+			}
 		}
 
 		dbRecord := locodedb.Record{
-			Location:   tableRecord.NameWoDiacritics,
-			SubDivCode: tableRecord.SubDiv,
-			Point:      geoPoint,
+			Location:       tableRecord.NameWoDiacritics,
+			LocationNative: tableRecord.Name,
+			SubDivCode:     tableRecord.SubDiv,
+			Point:          geoPoint,
+			PointSource:    pointSource,
+			Accuracy:       accuracy,
+			IATA:           tableRecord.IATA,
+			Removed:        tableRecord.Change == ChangeRemoved,
 		}
 
-		if countryName == "" {
-			countryName, err = names.CountryName(dbKey.CountryCode())
+		// Malformed classifier, status or date is not a reason to drop the
+		// location, they are treated as unknown and reported then.
+		dbRecord.Functions, err = locodedb.FunctionsFromString(tableRecord.Function)
+		if err != nil {
+			warnings = append(warnings, WarnInvalidFunction)
+		}
+		dbRecord.Status, err = locodedb.StatusFromString(tableRecord.Status)
+		if err != nil {
+			warnings = append(warnings, WarnInvalidStatus)
+		}
+		if tableRecord.Date != "" {
+			dbRecord.Updated, err = DateFromString(tableRecord.Date)
 			if err != nil {
-				if errors.Is(err, ErrCountryNotFound) {
-					return nil
-				}
+				warnings = append(warnings, WarnInvalidDate)
+			}
+		}
 
+		countryName, err := names.CountryName(dbKey.CountryCode())
+		if err != nil {
+			if !errors.Is(err, ErrCountryNotFound) {
 				return err
 			}
+			if airportCountryName == "" {
+				o.report.add(dbKey, SkipUnknownCountry)
+				return nil
+			}
+
+			countryName = airportCountryName
 		}
 
 		dbRecord.Country = countryName
 
+		// Unknown subdivision is not a reason to drop the location, it's
+		// kept with the code only.
 		if subDivCode := dbRecord.SubDivCode; subDivCode != "" {
 			subDivName, err := names.SubDivName(dbKey.CountryCode(), subDivCode)
-			if err != nil {
-				if errors.Is(err, ErrSubDivNotFound) {
-					return nil
-				}
+			if err != nil && !errors.Is(err, ErrSubDivNotFound) {
+				return err
+			}
 
+			subDivType, err := names.SubDivType(dbKey.CountryCode(), subDivCode)
+			if err != nil && !errors.Is(err, ErrSubDivNotFound) {
 				return err
 			}
 
 			dbRecord.SubDivName = subDivName
+			dbRecord.SubDivType = subDivType
 		}
 
-		continent, err := continents.PointContinent(geoPoint)
-		if err != nil {
-			return fmt.Errorf("could not calculate continent geo point: %w", err)
-		} else if *continent == locodedb.ContinentUnknown {
-			return nil
-		}
+		if pointSource == locodedb.PointSourceNone {
+			// Continent is unknown unless overridden.
+			dbRecord.Cont, err = o.patchContinent(tableRecord.LOCODE, locodedb.ContinentUnknown)
+			if err != nil {
+				return err
+			}
+		} else {
+			continent, err := continents.PointContinent(geoPoint)
+			if err != nil {
+				return fmt.Errorf("could not calculate continent geo point: %w", err)
+			}
+
+			dbRecord.Cont, err = o.patchContinent(tableRecord.LOCODE, *continent)
+			if err != nil {
+				return err
+			} else if dbRecord.Cont == locodedb.ContinentUnknown {
+				o.report.add(dbKey, SkipUnknownContinent)
+				return nil
+			}
 
-		dbRecord.Cont = *continent
+			if o.timeZones != nil {
+				tz, err := o.timeZones.TimeZone(dbKey.CountryCode(), geoPoint)
+				if err != nil && !errors.Is(err, ErrTimeZoneNotFound) {
+					return fmt.Errorf("could not calculate time zone: %w", err)
+				}
+
+				dbRecord.TimeZone = tz
+			}
+		}
 
 		newData = append(newData, Data{*dbKey, dbRecord})
+		for _, w := range warnings {
+			o.report.warn(dbKey, w)
+		}
 
 		return nil
+	}
+
+	if err := table.IterateAll(func(tableRecord Record) error {
+		if tableRecord.Change == ChangeReference {
+			alias, _, ok := strings.Cut(tableRecord.Name, referenceSeparator)
+			if !ok {
+				return nil
+			}
+			aliasWoDiacritics, official, ok := strings.Cut(tableRecord.NameWoDiacritics, referenceSeparator)
+			if !ok || aliasWoDiacritics == official {
+				return nil
+			}
+			key := [2]string{tableRecord.LOCODE[0], official}
+			if !slices.Contains(aliases[key], alias) {
+				aliases[key] = append(aliases[key], alias)
+			}
+			return nil
+		}
+
+		if tableRecord.LOCODE[1] == "" {
+			return nil
+		}
+
+		dbKey, err := NewKey(tableRecord.LOCODE[0], tableRecord.LOCODE[1])
+		if err != nil {
+			return err
+		}
+
+		coordinates := tableRecord.Coordinates
+		if o.overrides != nil {
+			keep, err := o.overrides.Patch(&tableRecord)
+			if err != nil {
+				return fmt.Errorf("could not apply overrides: %w", err)
+			}
+			if !keep {
+				o.report.add(dbKey, SkipDeleted)
+				return nil
+			}
+		}
+
+		return fill(dbKey, tableRecord, tableRecord.Coordinates != coordinates)
 	}); err != nil {
 		return err
 	}
 
+	if o.overrides != nil {
+		if err := o.overrides.IterateAdded(func(tableRecord Record) error {
+			dbKey, err := NewKey(tableRecord.LOCODE[0], tableRecord.LOCODE[1])
+			if err != nil {
+				return err
+			}
+
+			return fill(dbKey, tableRecord, true)
+		}); err != nil {
+			return err
+		}
+	}
+
+	o.report.finalize(newData)
+
+	if err := o.report.checkSkipped(o.maxSkipped); err != nil {
+		return err
+	}
+
+	for i := range newData {
+		all := aliases[[2]string{newData[i].Key.CountryCode(), newData[i].Record.Location}]
+		newData[i].Record.Aliases = capAliases(all, math.MaxUint8)
+		if len(newData[i].Record.Aliases) != len(all) {
+			o.report.warn(&newData[i].Key, WarnTooManyAliases)
+		}
+	}
+
 	if err := db.Put(newData); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// patchContinent applies the continent override if WithOverrides option is
+// used.
+func (o *options) patchContinent(lc [2]string, c locodedb.Continent) (locodedb.Continent, error) {
+	if o.overrides == nil {
+		return c, nil
+	}
+
+	c, err := o.overrides.PatchContinent(lc, c)
+	if err != nil {
+		return c, fmt.Errorf("could not apply overrides: %w", err)
+	}
+
+	return c, nil
+}
+
+// capAliases returns the aliases that fit into n bytes being joined, others
+// are dropped as a whole.
+func capAliases(aliases []string, n int) []string {
+	var (
+		res []string
+		l   = -len(aliasSeparator)
+	)
+	for _, a := range aliases {
+		if l+len(aliasSeparator)+len(a) > n {
+			continue
+		}
+		l += len(aliasSeparator) + len(a)
+		res = append(res, a)
+	}
+	return res
+}
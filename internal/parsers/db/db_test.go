@@ -0,0 +1,374 @@
+package locodedb
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+type testTable []Record
+
+func (t testTable) IterateAll(f func(Record) error) error {
+	for i := range t {
+		if err := f(t[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testAirports struct{}
+
+func (testAirports) Get(Record) (*AirportRecord, error) {
+	return nil, ErrAirportNotFound
+}
+
+type testContinents struct{}
+
+func (testContinents) PointContinent(locodedb.Point) (*locodedb.Continent, error) {
+	c := locodedb.Continent(locodedb.ContinentEurope)
+	return &c, nil
+}
+
+type testNames struct{}
+
+func (testNames) CountryName(string) (string, error) {
+	return "Russia", nil
+}
+
+func (testNames) SubDivName(string, string) (string, error) {
+	return "", ErrSubDivNotFound
+}
+
+func (testNames) SubDivType(string, string) (string, error) {
+	return "", ErrSubDivNotFound
+}
+
+func TestFillDatabase(t *testing.T) {
+	testCases := []struct {
+		name        string
+		coordinates string
+		iata        string
+		function    string
+		status      string
+		date        string
+		skipNoPoint bool
+		// wantSkip is the skip reason, the record is expected to be kept
+		// if it's empty.
+		wantSkip     string
+		wantSource   locodedb.PointSource
+		wantCont     locodedb.Continent
+		wantIATA     string
+		wantWarnings []Warning
+	}{
+		{
+			name:        "coordinates",
+			coordinates: "5545N 03737E",
+			wantSource:  locodedb.PointSourceUNLOCODE,
+			wantCont:    locodedb.ContinentEurope,
+		},
+		{
+			name:       "no coordinates",
+			wantSource: locodedb.PointSourceNone,
+			wantCont:   locodedb.ContinentUnknown,
+		},
+		{
+			name:        "no coordinates without missing points",
+			skipNoPoint: true,
+			wantSkip:    "no_airport",
+		},
+		{
+			name:        "malformed coordinates",
+			coordinates: "5545N",
+			wantSource:  locodedb.PointSourceNone,
+			wantCont:    locodedb.ContinentUnknown,
+		},
+		{
+			name:        "malformed coordinates without missing points",
+			coordinates: "5545N",
+			skipNoPoint: true,
+			wantSkip:    "invalid_coordinates",
+		},
+		{
+			name:        "IATA",
+			coordinates: "5545N 03737E",
+			iata:        "SVO",
+			wantSource:  locodedb.PointSourceUNLOCODE,
+			wantCont:    locodedb.ContinentEurope,
+			wantIATA:    "SVO",
+		},
+		{
+			name:         "short IATA",
+			coordinates:  "5545N 03737E",
+			iata:         "SV",
+			wantSource:   locodedb.PointSourceUNLOCODE,
+			wantCont:     locodedb.ContinentEurope,
+			wantWarnings: []Warning{{"RUMOW", WarnInvalidIATA}},
+		},
+		{
+			name:         "lowercase IATA",
+			coordinates:  "5545N 03737E",
+			iata:         "svo",
+			wantSource:   locodedb.PointSourceUNLOCODE,
+			wantCont:     locodedb.ContinentEurope,
+			wantWarnings: []Warning{{"RUMOW", WarnInvalidIATA}},
+		},
+		{
+			name:         "invalid function",
+			coordinates:  "5545N 03737E",
+			function:     "1234",
+			wantSource:   locodedb.PointSourceUNLOCODE,
+			wantCont:     locodedb.ContinentEurope,
+			wantWarnings: []Warning{{"RUMOW", WarnInvalidFunction}},
+		},
+		{
+			name:         "invalid status",
+			coordinates:  "5545N 03737E",
+			status:       "ZZ",
+			wantSource:   locodedb.PointSourceUNLOCODE,
+			wantCont:     locodedb.ContinentEurope,
+			wantWarnings: []Warning{{"RUMOW", WarnInvalidStatus}},
+		},
+		{
+			name:         "invalid date",
+			coordinates:  "5545N 03737E",
+			date:         "0613",
+			wantSource:   locodedb.PointSourceUNLOCODE,
+			wantCont:     locodedb.ContinentEurope,
+			wantWarnings: []Warning{{"RUMOW", WarnInvalidDate}},
+		},
+		{
+			name:        "invalid IATA without missing points",
+			iata:        "SV",
+			skipNoPoint: true,
+			wantSkip:    "no_airport",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				dir    = t.TempDir()
+				report Report
+				opts   = []Option{WithReport(&report)}
+				table  = testTable{{
+					LOCODE:           [2]string{"RU", "MOW"},
+					Name:             "Moskva",
+					NameWoDiacritics: "Moskva",
+					Coordinates:      tc.coordinates,
+					IATA:             tc.iata,
+					Function:         tc.function,
+					Status:           tc.status,
+					Date:             tc.date,
+				}}
+			)
+			if tc.skipNoPoint {
+				opts = append(opts, WithoutMissingPoints())
+			}
+
+			err := FillDatabase(table, testAirports{}, testContinents{}, testNames{}, New(dir), opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			f, err := os.Open(filepath.Join(dir, filenameCSVLocode))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer f.Close()
+			rows, err := csv.NewReader(f).ReadAll()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.wantSkip != "" {
+				if len(rows) != 0 {
+					t.Errorf("got %d records, want none", len(rows))
+				}
+				if len(report.Skipped) != 1 || report.Skipped[0].Reason.String() != tc.wantSkip {
+					t.Errorf("got skipped %v, want %s", report.Skipped, tc.wantSkip)
+				}
+				if len(report.Warnings) != 0 {
+					t.Errorf("got warnings %v for skipped record", report.Warnings)
+				}
+				return
+			}
+
+			if len(rows) != 1 {
+				t.Fatalf("got %d records, want 1", len(rows))
+			}
+			if len(report.Skipped) != 0 {
+				t.Errorf("got skipped %v, want none", report.Skipped)
+			}
+			if got := rows[0][PointSourceRecordNum]; got != tc.wantSource.String() {
+				t.Errorf("got point source %q, want %q", got, tc.wantSource)
+			}
+			if got := rows[0][ContRecordNum]; got != strconv.Itoa(int(tc.wantCont)) {
+				t.Errorf("got continent %s, want %d", got, tc.wantCont)
+			}
+			if got := rows[0][9]; got != tc.wantIATA {
+				t.Errorf("got IATA %q, want %q", got, tc.wantIATA)
+			}
+			if !slices.Equal(report.Warnings, tc.wantWarnings) {
+				t.Errorf("got warnings %v, want %v", report.Warnings, tc.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestFillDatabaseMaxSkipped(t *testing.T) {
+	table := testTable{
+		{LOCODE: [2]string{"RU", "MOW"}, Name: "Moskva", NameWoDiacritics: "Moskva", Coordinates: "5545N 03737E"},
+		{LOCODE: [2]string{"RU", "ZZZ"}, Name: "Nowhere", NameWoDiacritics: "Nowhere"},
+	}
+
+	testCases := []struct {
+		name    string
+		limits  map[SkipReason]int
+		wantErr bool
+	}{
+		{name: "within limit", limits: map[SkipReason]int{SkipNoAirport: 1}},
+		{name: "other reason", limits: map[SkipReason]int{SkipRemoved: 0}},
+		{name: "exceeded", limits: map[SkipReason]int{SkipNoAirport: 0}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			err := FillDatabase(table, testAirports{}, testContinents{}, testNames{}, New(dir),
+				WithoutMissingPoints(), WithMaxSkipped(tc.limits))
+
+			_, statErr := os.Stat(filepath.Join(dir, filenameCSVLocode))
+			if tc.wantErr {
+				if !errors.Is(err, ErrTooManySkipped) {
+					t.Fatalf("got error %v, want %v", err, ErrTooManySkipped)
+				}
+				if !errors.Is(statErr, os.ErrNotExist) {
+					t.Errorf("database is written: %v", statErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if statErr != nil {
+				t.Errorf("database is not written: %v", statErr)
+			}
+		})
+	}
+}
+
+func TestFillDatabaseAliases(t *testing.T) {
+	var many []string
+	for i := range 40 {
+		many = append(many, fmt.Sprintf("Alias number %02d", i))
+	}
+
+	testCases := []struct {
+		name         string
+		aliases      []string
+		want         []string
+		wantWarnings []Warning
+	}{
+		{
+			name:    "fit",
+			aliases: []string{"Moscow", "Moskau"},
+			want:    []string{"Moscow", "Moskau"},
+		},
+		{
+			// 16 aliases of 15 bytes with 15 separators are 255 bytes.
+			name:         "overflow",
+			aliases:      many,
+			want:         many[:16],
+			wantWarnings: []Warning{{"RUMOW", WarnTooManyAliases}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				dir    = t.TempDir()
+				report Report
+				table  = testTable{{
+					LOCODE:           [2]string{"RU", "MOW"},
+					Name:             "Moskva",
+					NameWoDiacritics: "Moskva",
+					Coordinates:      "5545N 03737E",
+				}}
+			)
+			for _, a := range tc.aliases {
+				table = append(table, Record{
+					Change:           ChangeReference,
+					LOCODE:           [2]string{"RU", ""},
+					Name:             a + referenceSeparator + "Moskva",
+					NameWoDiacritics: a + referenceSeparator + "Moskva",
+				})
+			}
+
+			err := FillDatabase(table, testAirports{}, testContinents{}, testNames{}, New(dir), WithReport(&report))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			f, err := os.Open(filepath.Join(dir, filenameCSVLocode))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer f.Close()
+			rows, err := csv.NewReader(f).ReadAll()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(rows) != 1 {
+				t.Fatalf("got %d records, want 1", len(rows))
+			}
+			if got := strings.Split(rows[0][13], aliasSeparator); !slices.Equal(got, tc.want) {
+				t.Errorf("got aliases %v, want %v", got, tc.want)
+			}
+			if len(report.Skipped) != 0 {
+				t.Errorf("got skipped %v, want none", report.Skipped)
+			}
+			if !slices.Equal(report.Warnings, tc.wantWarnings) {
+				t.Errorf("got warnings %v, want %v", report.Warnings, tc.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestCapAliases(t *testing.T) {
+	var many []string
+	for i := range 40 {
+		many = append(many, fmt.Sprintf("Alias number %02d", i))
+	}
+
+	testCases := []struct {
+		name    string
+		aliases []string
+		want    []string
+	}{
+		{name: "empty"},
+		{name: "fit", aliases: []string{"Moscow", "Moskau"}, want: []string{"Moscow", "Moskau"}},
+		// 16 aliases of 15 bytes with 15 separators are 255 bytes.
+		{name: "overflow", aliases: many, want: many[:16]},
+		{name: "long alias", aliases: []string{strings.Repeat("x", 256), "Moscow"}, want: []string{"Moscow"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := capAliases(tc.aliases, math.MaxUint8)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if l := len(strings.Join(got, aliasSeparator)); l > math.MaxUint8 {
+				t.Errorf("got %d bytes", l)
+			}
+		})
+	}
+}
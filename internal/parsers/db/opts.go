@@ -0,0 +1,73 @@
+package locodedb
+
+// Option sets an optional parameter of FillDatabase.
+type Option func(*options)
+
+type options struct {
+	skipRemoved bool
+
+	skipNoPoint bool
+
+	timeZones TimeZonesDB
+
+	overrides OverrideDB
+
+	report *Report
+
+	maxSkipped map[SkipReason]int
+}
+
+func defaultOpts() *options {
+	return &options{}
+}
+
+// WithoutRemoved returns an option to skip entries marked for removal by the
+// "X" change indicator. By default, they are kept and flagged.
+func WithoutRemoved() Option {
+	return func(o *options) {
+		o.skipRemoved = true
+	}
+}
+
+// WithTimeZones returns an option to set time zones of the locations using
+// the given database. By default, time zones are not set.
+func WithTimeZones(tz TimeZonesDB) Option {
+	return func(o *options) {
+		o.timeZones = tz
+	}
+}
+
+// WithReport returns an option to collect records skipped by FillDatabase
+// into the given report.
+func WithReport(r *Report) Option {
+	return func(o *options) {
+		o.report = r
+	}
+}
+
+// WithoutMissingPoints returns an option to skip entries without coordinates
+// or with malformed ones and without matching airport. By default, they are
+// kept without a point and with an unknown continent.
+func WithoutMissingPoints() Option {
+	return func(o *options) {
+		o.skipNoPoint = true
+	}
+}
+
+// WithOverrides returns an option to apply local corrections of the UN/LOCODE
+// table: changed fields, added and deleted entries. By default, the table is
+// used as is.
+func WithOverrides(ov OverrideDB) Option {
+	return func(o *options) {
+		o.overrides = ov
+	}
+}
+
+// WithMaxSkipped returns an option to fail if the number of skipped records
+// exceeds the limit for any reason given. The database is not written then.
+// By default, there are no limits.
+func WithMaxSkipped(limits map[SkipReason]int) Option {
+	return func(o *options) {
+		o.maxSkipped = limits
+	}
+}
@@ -1,6 +1,7 @@
 package locodedb
 
 import (
+	"math"
 	"testing"
 
 	"github.com/nspcc-dev/locode-db/pkg/locodedb"
@@ -172,3 +173,39 @@ func pointFromString(s string) (locodedb.Point, error) {
 
 	return point, nil
 }
+
+func TestCoordinatesAccuracy(t *testing.T) {
+	testCases := []struct {
+		name         string
+		coordsGot    string
+		accuracyWant float64
+	}{
+		{
+			name:         "Degree-minute coordinates",
+			coordsGot:    "5915N 01806E",
+			accuracyWant: 1.852,
+		},
+		{
+			name:         "Decimal degree coordinates",
+			coordsGot:    "26.8618N 89.3748E",
+			accuracyWant: 0.011132,
+		},
+		{
+			name:         "Decimal degree coordinates, the worst is used",
+			coordsGot:    "26.8N 89.3748E",
+			accuracyWant: 11.132,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			crd, err := CoordinatesFromString(test.coordsGot)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := crd.Accuracy(); math.Abs(got-test.accuracyWant) > 1e-9 {
+				t.Errorf("got = %v, want %v", got, test.accuracyWant)
+			}
+		})
+	}
+}
@@ -3,10 +3,13 @@ package locodedb
 import (
 	"cmp"
 	"encoding/csv"
+	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/nspcc-dev/locode-db/pkg/locodedb"
 )
@@ -15,10 +18,28 @@ const (
 	filenameCSVLocode    = "locodes.csv"
 	filenameCSVCountries = "countries.csv"
 
+	// ContRecordNum is number of continent column in the locode data record.
+	ContRecordNum = 2
 	// LatRecordNum is number of latitude column in the locode data record.
 	LatRecordNum = 5
 	// LngRecordNum is number of longitude column in the locode data record.
 	LngRecordNum = 6
+	// PointSourceRecordNum is number of point source column in the locode
+	// data record.
+	PointSourceRecordNum = 15
+	// AccuracyRecordNum is number of point accuracy column in the locode
+	// data record.
+	AccuracyRecordNum = 16
+	// TimeZoneRecordNum is number of time zone column in the locode data
+	// record.
+	TimeZoneRecordNum = 17
+
+	// dateLayout is the format of the last change date in the locode data
+	// record.
+	dateLayout = "2006-01"
+
+	// aliasSeparator separates alternative names in the locode data record.
+	aliasSeparator = "|"
 )
 
 // Data is a struct that contains the Key and the Record.
@@ -43,23 +64,84 @@ func (db *CsvDB) Put(data []Data) error {
 		keyString := key.CountryCode() + key.LocationCode()
 
 		if index, exists := uniqueKeys[keyString]; exists {
-			// We expected duplicates from override.csv to override wrong number format in location
+			if rec.PointSource == locodedb.PointSourceNone {
+				continue
+			}
+			if newRecordsLocode[index][PointSourceRecordNum] == locodedb.PointSourceNone.String() {
+				// Continent of the location without a point is unknown.
+				newRecordsLocode[index][ContRecordNum] = strconv.Itoa(int(rec.Cont))
+			}
+			// Duplicates from extra tables override the point of the location,
+			// see also WithOverrides for a better way to correct it. Only
+			// coordinates given by the duplicate are marked as override.
+			pointSource := rec.PointSource
+			if pointSource == locodedb.PointSourceUNLOCODE {
+				pointSource = locodedb.PointSourceOverride
+			}
 			newRecordsLocode[index][LatRecordNum] = strconv.FormatFloat(float64(rec.Point.Latitude), 'f', -1, 32)
 			newRecordsLocode[index][LngRecordNum] = strconv.FormatFloat(float64(rec.Point.Longitude), 'f', -1, 32)
+			newRecordsLocode[index][PointSourceRecordNum] = pointSource.String()
+			newRecordsLocode[index][AccuracyRecordNum] = formatAccuracy(rec.Accuracy)
+			newRecordsLocode[index][TimeZoneRecordNum] = rec.TimeZone
 			continue
 		}
 
 		// Mark the index as seen
 		uniqueKeys[keyString] = len(newRecordsLocode)
 
+		// Native name is stored only if it's different.
+		native := rec.LocationNative
+		if native == rec.Location {
+			native = ""
+		}
+		if err := checkLen(keyString, "native name", native); err != nil {
+			return err
+		}
+
+		if rec.IATA != "" && !IsIATA(rec.IATA) {
+			return fmt.Errorf("%s: invalid IATA code %q", keyString, rec.IATA)
+		}
+
+		var updated string
+		if !rec.Updated.IsZero() {
+			updated = rec.Updated.Format(dateLayout)
+		}
+
+		var removed string
+		if rec.Removed {
+			removed = ChangeRemoved
+		}
+
+		aliases := strings.Join(rec.Aliases, aliasSeparator)
+		if err := checkLen(keyString, "aliases", aliases); err != nil {
+			return err
+		}
+
+		lat := strconv.FormatFloat(float64(rec.Point.Latitude), 'f', -1, 32)
+		lng := strconv.FormatFloat(float64(rec.Point.Longitude), 'f', -1, 32)
+		if rec.PointSource == locodedb.PointSourceNone {
+			lat, lng = "", ""
+		}
+
 		newRecord := []string{
 			keyString,
 			rec.Location,
 			strconv.Itoa(int(rec.Cont)),
 			rec.SubDivCode,
 			rec.SubDivName,
-			strconv.FormatFloat(float64(rec.Point.Latitude), 'f', -1, 32),
-			strconv.FormatFloat(float64(rec.Point.Longitude), 'f', -1, 32),
+			lat,
+			lng,
+			rec.Functions.String(),
+			rec.Status.String(),
+			rec.IATA,
+			native,
+			updated,
+			removed,
+			aliases,
+			rec.SubDivType,
+			rec.PointSource.String(),
+			formatAccuracy(rec.Accuracy),
+			rec.TimeZone,
 		}
 
 		newRecordsLocode = append(newRecordsLocode, newRecord)
@@ -119,3 +201,21 @@ func writeToCsvFile(newRecords [][]string, path string) error {
 
 	return nil
 }
+
+// formatAccuracy formats point accuracy in kilometers, unknown (zero)
+// accuracy is an empty string.
+func formatAccuracy(a float64) string {
+	if a == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(a, 'f', -1, 32)
+}
+
+// checkLen returns an error if the value of the locode data record column is
+// too long to be loaded by pkg/locodedb.
+func checkLen(key, column, value string) error {
+	if len(value) > math.MaxUint8 {
+		return fmt.Errorf("%s: %s is %d bytes long, at most %d bytes are allowed", key, column, len(value), math.MaxUint8)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package locodedb
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+func TestPut(t *testing.T) {
+	key, err := NewKey("RU", "MOW")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		record locodedb.Record
+		// duplicate is put after the record with the same key.
+		duplicate *locodedb.Record
+		wantCol   int
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:    "native name",
+			record:  locodedb.Record{Location: "Moskva", LocationNative: "Москва"},
+			wantCol: 10,
+			want:    "Москва",
+		},
+		{
+			name:    "same native name",
+			record:  locodedb.Record{Location: "Moskva", LocationNative: "Moskva"},
+			wantCol: 10,
+		},
+		{
+			name:    "aliases",
+			record:  locodedb.Record{Location: "Moskva", Aliases: []string{"Moscow", "Moskau"}},
+			wantCol: 13,
+			want:    "Moscow|Moskau",
+		},
+		{
+			name:      "duplicate with coordinates",
+			record:    locodedb.Record{Location: "Moskva", PointSource: locodedb.PointSourceNone},
+			duplicate: &locodedb.Record{Location: "Moskva", Point: locodedb.Point{Latitude: 55.75, Longitude: 37.61}, PointSource: locodedb.PointSourceUNLOCODE},
+			wantCol:   PointSourceRecordNum,
+			want:      locodedb.PointSourceOverride.String(),
+		},
+		{
+			name:      "duplicate with airport",
+			record:    locodedb.Record{Location: "Moskva", PointSource: locodedb.PointSourceNone},
+			duplicate: &locodedb.Record{Location: "Moskva", Point: locodedb.Point{Latitude: 55.97, Longitude: 37.41}, PointSource: locodedb.PointSourceAirport},
+			wantCol:   PointSourceRecordNum,
+			want:      locodedb.PointSourceAirport.String(),
+		},
+		{
+			name:      "duplicate time zone",
+			record:    locodedb.Record{Location: "Moskva", PointSource: locodedb.PointSourceNone},
+			duplicate: &locodedb.Record{Location: "Moskva", Point: locodedb.Point{Latitude: 55.75, Longitude: 37.61}, PointSource: locodedb.PointSourceUNLOCODE, TimeZone: "Europe/Moscow"},
+			wantCol:   TimeZoneRecordNum,
+			want:      "Europe/Moscow",
+		},
+		{
+			name:      "duplicate moved time zone",
+			record:    locodedb.Record{Location: "Moskva", Point: locodedb.Point{Latitude: 55.75, Longitude: 37.61}, PointSource: locodedb.PointSourceUNLOCODE, TimeZone: "Europe/Moscow"},
+			duplicate: &locodedb.Record{Location: "Moskva", Point: locodedb.Point{Latitude: 55.03, Longitude: 82.92}, PointSource: locodedb.PointSourceUNLOCODE, TimeZone: "Asia/Novosibirsk"},
+			wantCol:   TimeZoneRecordNum,
+			want:      "Asia/Novosibirsk",
+		},
+		{
+			name:      "duplicate without point",
+			record:    locodedb.Record{Location: "Moskva", Point: locodedb.Point{Latitude: 55.75, Longitude: 37.61}, PointSource: locodedb.PointSourceUNLOCODE},
+			duplicate: &locodedb.Record{Location: "Moskva", PointSource: locodedb.PointSourceNone},
+			wantCol:   PointSourceRecordNum,
+			want:      locodedb.PointSourceUNLOCODE.String(),
+		},
+		{
+			name:    "too many aliases",
+			record:  locodedb.Record{Location: "Moskva", Aliases: slices.Repeat([]string{"Moscow"}, 40)},
+			wantErr: true,
+		},
+		{
+			name:    "IATA",
+			record:  locodedb.Record{Location: "Moskva", IATA: "SVO"},
+			wantCol: 9,
+			want:    "SVO",
+		},
+		{
+			name:    "invalid IATA",
+			record:  locodedb.Record{Location: "Moskva", IATA: "SV"},
+			wantErr: true,
+		},
+		{
+			name:    "long native name",
+			record:  locodedb.Record{Location: "Moskva", LocationNative: strings.Repeat("Москва", 22)},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			data := []Data{{Key: *key, Record: tc.record}}
+			if tc.duplicate != nil {
+				data = append(data, Data{Key: *key, Record: *tc.duplicate})
+			}
+
+			db := New(dir)
+			err := db.Put(data)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("error expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			b, err := os.ReadFile(filepath.Join(dir, filenameCSVLocode))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cols := strings.Split(strings.TrimSpace(string(b)), ",")
+			if got := cols[tc.wantCol]; got != tc.want {
+				t.Errorf("got column %d = %q, want %q", tc.wantCol, got, tc.want)
+			}
+		})
+	}
+}
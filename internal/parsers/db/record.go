@@ -2,6 +2,10 @@ package locodedb
 
 // Record represents a single record of the UN/LOCODE table.
 type Record struct {
+	// Change indicator showing the type of change of the entry in the last
+	// issue ("+", "#", "|", "=", "X" or empty).
+	Change string
+
 	// Combination of a 2-character country code and a 3-character location code.
 	LOCODE [2]string
 
@@ -32,3 +36,33 @@ type Record struct {
 	// Some general remarks regarding the UN/LOCODE in question.
 	Remarks string
 }
+
+// Change indicators of UN/LOCODE table records handled specially.
+const (
+	// ChangeRemoved marks an entry to be removed in the next issue.
+	ChangeRemoved = "X"
+
+	// ChangeReference marks a reference entry, its name contains an
+	// alternative name and the official one separated by " = ".
+	ChangeReference = "="
+)
+
+// referenceSeparator separates names in the reference entry.
+const referenceSeparator = " = "
+
+// iataLen is the length of IATA code.
+const iataLen = 3
+
+// IsIATA checks whether s is a valid IATA code: 3 uppercase Latin letters or
+// digits.
+func IsIATA(s string) bool {
+	if len(s) != iataLen {
+		return false
+	}
+	for i := range len(s) {
+		if (s[i] < 'A' || s[i] > 'Z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,313 @@
+package locodedb
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// SkipReason is a reason for the UN/LOCODE table record to be skipped by
+// FillDatabase.
+type SkipReason uint8
+
+const (
+	// SkipInvalidCoordinates is used for records with malformed coordinates
+	// if WithoutMissingPoints option is used.
+	SkipInvalidCoordinates SkipReason = iota
+
+	// SkipNoAirport is used for records without coordinates and without
+	// matching airport if WithoutMissingPoints option is used.
+	SkipNoAirport
+
+	// SkipUnknownCountry is used for records of unknown countries.
+	SkipUnknownCountry
+
+	// SkipUnknownContinent is used for records with the point outside of
+	// any continent.
+	SkipUnknownContinent
+
+	// SkipRemoved is used for records marked for removal if WithoutRemoved
+	// option is used.
+	SkipRemoved
+
+	// SkipDeleted is used for records deleted by overrides.
+	SkipDeleted
+
+	skipReasonNum
+)
+
+// skipReasonNames are string representations of SkipReason values.
+var skipReasonNames = [...]string{
+	SkipInvalidCoordinates: "invalid_coordinates",
+	SkipNoAirport:          "no_airport",
+	SkipUnknownCountry:     "unknown_country",
+	SkipUnknownContinent:   "unknown_continent",
+	SkipRemoved:            "removed",
+	SkipDeleted:            "deleted",
+}
+
+// String returns a string representation of the SkipReason like
+// "no_airport".
+func (r SkipReason) String() string {
+	if r >= skipReasonNum {
+		return "unknown"
+	}
+	return skipReasonNames[r]
+}
+
+// SkipReasonFromString returns SkipReason value corresponding to the passed
+// string representation.
+func SkipReasonFromString(s string) (SkipReason, error) {
+	for i := range skipReasonNames {
+		if skipReasonNames[i] == s {
+			return SkipReason(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown skip reason %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r SkipReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// WarnReason is a reason for the UN/LOCODE table record to be reported by
+// FillDatabase while it's kept with some data dropped.
+type WarnReason uint8
+
+const (
+	// WarnTooManyAliases is used for locations with reference entries
+	// dropped because all aliases don't fit into the locode data record.
+	WarnTooManyAliases WarnReason = iota
+
+	// WarnInvalidIATA is used for records with IATA code that is not 3
+	// uppercase Latin letters or digits, the code is dropped.
+	WarnInvalidIATA
+
+	// WarnInvalidFunction is used for records with malformed function
+	// classifier, functions are unknown then.
+	WarnInvalidFunction
+
+	// WarnInvalidStatus is used for records with unknown status code, status
+	// is unknown then.
+	WarnInvalidStatus
+
+	// WarnInvalidDate is used for records with malformed last change date,
+	// the date is unknown then.
+	WarnInvalidDate
+
+	warnReasonNum
+)
+
+// warnReasonNames are string representations of WarnReason values.
+var warnReasonNames = [...]string{
+	WarnTooManyAliases:  "too_many_aliases",
+	WarnInvalidIATA:     "invalid_iata",
+	WarnInvalidFunction: "invalid_function",
+	WarnInvalidStatus:   "invalid_status",
+	WarnInvalidDate:     "invalid_date",
+}
+
+// String returns a string representation of the WarnReason like
+// "too_many_aliases".
+func (r WarnReason) String() string {
+	if r >= warnReasonNum {
+		return "unknown"
+	}
+	return warnReasonNames[r]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r WarnReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// Skipped is a UN/LOCODE table record skipped by FillDatabase.
+type Skipped struct {
+	// LOCODE without space separator.
+	LOCODE string `json:"locode"`
+
+	Reason SkipReason `json:"reason"`
+}
+
+// Warning is a UN/LOCODE table record kept by FillDatabase with some data
+// dropped.
+type Warning struct {
+	// LOCODE without space separator.
+	LOCODE string `json:"locode"`
+
+	Reason WarnReason `json:"reason"`
+}
+
+// Report is a list of records skipped by FillDatabase and warnings about the
+// kept ones, see WithReport.
+type Report struct {
+	Skipped []Skipped
+
+	Warnings []Warning
+}
+
+// add appends a skipped record to the report.
+func (r *Report) add(key *Key, reason SkipReason) {
+	if r == nil {
+		return
+	}
+	r.Skipped = append(r.Skipped, Skipped{
+		LOCODE: key.CountryCode() + key.LocationCode(),
+		Reason: reason,
+	})
+}
+
+// warn appends a warning about the kept record to the report.
+func (r *Report) warn(key *Key, reason WarnReason) {
+	if r == nil {
+		return
+	}
+	r.Warnings = append(r.Warnings, Warning{
+		LOCODE: key.CountryCode() + key.LocationCode(),
+		Reason: reason,
+	})
+}
+
+// finalize removes records that are present in the data (added by other
+// tables) and duplicates keeping the first occurrence.
+func (r *Report) finalize(data []Data) {
+	if r == nil {
+		return
+	}
+
+	seen := make(map[string]struct{}, len(data)+len(r.Skipped))
+	for i := range data {
+		seen[data[i].Key.CountryCode()+data[i].Key.LocationCode()] = struct{}{}
+	}
+
+	r.Skipped = slices.DeleteFunc(r.Skipped, func(s Skipped) bool {
+		if _, ok := seen[s.LOCODE]; ok {
+			return true
+		}
+		seen[s.LOCODE] = struct{}{}
+		return false
+	})
+}
+
+// ErrTooManySkipped is returned by FillDatabase if the number of skipped
+// records exceeds the limit, see WithMaxSkipped.
+var ErrTooManySkipped = errors.New("too many records skipped")
+
+// checkSkipped returns an error if the number of skipped records exceeds the
+// limit for any reason.
+func (r *Report) checkSkipped(limits map[SkipReason]int) error {
+	if r == nil {
+		return nil
+	}
+
+	totals := r.Totals()
+	for _, reason := range slices.Sorted(maps.Keys(limits)) {
+		if limit := limits[reason]; totals[reason] > limit {
+			return fmt.Errorf("%w for %s reason: %d > %d", ErrTooManySkipped, reason, totals[reason], limit)
+		}
+	}
+	return nil
+}
+
+// Totals returns the number of skipped records per reason.
+func (r *Report) Totals() map[SkipReason]int {
+	m := make(map[SkipReason]int)
+	for i := range r.Skipped {
+		m[r.Skipped[i].Reason]++
+	}
+	return m
+}
+
+// WarningTotals returns the number of warnings per reason.
+func (r *Report) WarningTotals() map[WarnReason]int {
+	m := make(map[WarnReason]int)
+	for i := range r.Warnings {
+		m[r.Warnings[i].Reason]++
+	}
+	return m
+}
+
+// WriteJSON writes the report as a JSON object with "totals" (map of reason
+// to the number of records) and "skipped" (list of records with "locode" and
+// "reason") fields. "warning_totals" and "warnings" fields of the same format
+// are added if there are warnings.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+
+	skipped := r.Skipped
+	if skipped == nil {
+		skipped = []Skipped{}
+	}
+
+	var warningTotals map[WarnReason]int
+	if len(r.Warnings) != 0 {
+		warningTotals = r.WarningTotals()
+	}
+
+	return enc.Encode(struct {
+		Totals        map[SkipReason]int `json:"totals"`
+		Skipped       []Skipped          `json:"skipped"`
+		WarningTotals map[WarnReason]int `json:"warning_totals,omitempty"`
+		Warnings      []Warning          `json:"warnings,omitempty"`
+	}{
+		Totals:        r.Totals(),
+		Skipped:       skipped,
+		WarningTotals: warningTotals,
+		Warnings:      r.Warnings,
+	})
+}
+
+// WriteCSV writes the report as CSV table with "locode,reason,total" header.
+// Skipped records go first with empty total, then totals per reason follow
+// with empty LOCODE. Warnings and their totals are written the same way
+// after them.
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"locode", "reason", "total"}); err != nil {
+		return err
+	}
+
+	for _, s := range r.Skipped {
+		if err := writer.Write([]string{s.LOCODE, s.Reason.String(), ""}); err != nil {
+			return err
+		}
+	}
+
+	totals := r.Totals()
+	for reason := range skipReasonNum {
+		if totals[reason] == 0 {
+			continue
+		}
+		if err := writer.Write([]string{"", reason.String(), strconv.Itoa(totals[reason])}); err != nil {
+			return err
+		}
+	}
+
+	for _, w := range r.Warnings {
+		if err := writer.Write([]string{w.LOCODE, w.Reason.String(), ""}); err != nil {
+			return err
+		}
+	}
+
+	warningTotals := r.WarningTotals()
+	for reason := range warnReasonNum {
+		if warningTotals[reason] == 0 {
+			continue
+		}
+		if err := writer.Write([]string{"", reason.String(), strconv.Itoa(warningTotals[reason])}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
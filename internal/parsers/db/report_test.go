@@ -0,0 +1,233 @@
+package locodedb
+
+import (
+	"bytes"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// newTestReport returns the report with the records added in order.
+func newTestReport(t *testing.T, skipped []Skipped) *Report {
+	t.Helper()
+
+	var r Report
+	for _, s := range skipped {
+		key, err := NewKey(s.LOCODE[:2], s.LOCODE[2:])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		r.add(key, s.Reason)
+	}
+	return &r
+}
+
+func TestReportFinalize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		skipped    []Skipped
+		data       []string
+		want       []Skipped
+		wantTotals map[SkipReason]int
+	}{
+		{
+			name:       "empty",
+			wantTotals: map[SkipReason]int{},
+		},
+		{
+			name:       "single",
+			skipped:    []Skipped{{"RUZZZ", SkipNoAirport}},
+			want:       []Skipped{{"RUZZZ", SkipNoAirport}},
+			wantTotals: map[SkipReason]int{SkipNoAirport: 1},
+		},
+		{
+			name:       "duplicate",
+			skipped:    []Skipped{{"RUZZZ", SkipNoAirport}, {"RUZZZ", SkipInvalidCoordinates}},
+			want:       []Skipped{{"RUZZZ", SkipNoAirport}},
+			wantTotals: map[SkipReason]int{SkipNoAirport: 1},
+		},
+		{
+			name:       "added by another table",
+			skipped:    []Skipped{{"RUMOW", SkipUnknownCountry}, {"RUOLD", SkipRemoved}},
+			data:       []string{"RUMOW"},
+			want:       []Skipped{{"RUOLD", SkipRemoved}},
+			wantTotals: map[SkipReason]int{SkipRemoved: 1},
+		},
+		{
+			name:       "totals",
+			skipped:    []Skipped{{"RUZZZ", SkipNoAirport}, {"RUOLD", SkipRemoved}, {"SEXXX", SkipNoAirport}},
+			want:       []Skipped{{"RUZZZ", SkipNoAirport}, {"RUOLD", SkipRemoved}, {"SEXXX", SkipNoAirport}},
+			wantTotals: map[SkipReason]int{SkipNoAirport: 2, SkipRemoved: 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newTestReport(t, tc.skipped)
+
+			var data []Data
+			for _, s := range tc.data {
+				key, err := NewKey(s[:2], s[2:])
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				data = append(data, Data{Key: *key})
+			}
+
+			r.finalize(data)
+			if !slices.Equal(r.Skipped, tc.want) {
+				t.Errorf("got skipped %v, want %v", r.Skipped, tc.want)
+			}
+			if got := r.Totals(); !maps.Equal(got, tc.wantTotals) {
+				t.Errorf("got totals %v, want %v", got, tc.wantTotals)
+			}
+		})
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	skipped := []Skipped{{"RUZZZ", SkipNoAirport}, {"RUOLD", SkipRemoved}, {"SEXXX", SkipNoAirport}}
+
+	testCases := []struct {
+		name     string
+		write    func(*Report, io.Writer) error
+		warnings []Warning
+		want     string
+	}{
+		{
+			name:  "CSV",
+			write: (*Report).WriteCSV,
+			want: `locode,reason,total
+RUZZZ,no_airport,
+RUOLD,removed,
+SEXXX,no_airport,
+,no_airport,2
+,removed,1
+`,
+		},
+		{
+			name:  "JSON",
+			write: (*Report).WriteJSON,
+			want: `{
+	"totals": {
+		"no_airport": 2,
+		"removed": 1
+	},
+	"skipped": [
+		{
+			"locode": "RUZZZ",
+			"reason": "no_airport"
+		},
+		{
+			"locode": "RUOLD",
+			"reason": "removed"
+		},
+		{
+			"locode": "SEXXX",
+			"reason": "no_airport"
+		}
+	]
+}
+`,
+		},
+		{
+			name:     "CSV with warnings",
+			write:    (*Report).WriteCSV,
+			warnings: []Warning{{"RUMOW", WarnTooManyAliases}},
+			want: `locode,reason,total
+RUZZZ,no_airport,
+RUOLD,removed,
+SEXXX,no_airport,
+,no_airport,2
+,removed,1
+RUMOW,too_many_aliases,
+,too_many_aliases,1
+`,
+		},
+		{
+			name:     "JSON with warnings",
+			write:    (*Report).WriteJSON,
+			warnings: []Warning{{"RUMOW", WarnTooManyAliases}},
+			want: `{
+	"totals": {
+		"no_airport": 2,
+		"removed": 1
+	},
+	"skipped": [
+		{
+			"locode": "RUZZZ",
+			"reason": "no_airport"
+		},
+		{
+			"locode": "RUOLD",
+			"reason": "removed"
+		},
+		{
+			"locode": "SEXXX",
+			"reason": "no_airport"
+		}
+	],
+	"warning_totals": {
+		"too_many_aliases": 1
+	},
+	"warnings": [
+		{
+			"locode": "RUMOW",
+			"reason": "too_many_aliases"
+		}
+	]
+}
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newTestReport(t, skipped)
+			r.Warnings = tc.warnings
+
+			var b bytes.Buffer
+			if err := tc.write(r, &b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if b.String() != tc.want {
+				t.Errorf("got %q, want %q", b.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestSkipReasonFromString(t *testing.T) {
+	testCases := []struct {
+		name    string
+		s       string
+		want    SkipReason
+		wantErr bool
+	}{
+		{name: "invalid coordinates", s: "invalid_coordinates", want: SkipInvalidCoordinates},
+		{name: "no airport", s: "no_airport", want: SkipNoAirport},
+		{name: "unknown country", s: "unknown_country", want: SkipUnknownCountry},
+		{name: "unknown continent", s: "unknown_continent", want: SkipUnknownContinent},
+		{name: "removed", s: "removed", want: SkipRemoved},
+		{name: "deleted", s: "deleted", want: SkipDeleted},
+		{name: "too many aliases", s: "too_many_aliases", wantErr: true},
+		{name: "bogus", s: "bogus", wantErr: true},
+		{name: "empty", s: "", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SkipReasonFromString(tc.s)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("error expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
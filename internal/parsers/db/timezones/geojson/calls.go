@@ -0,0 +1,68 @@
+package timezonesdb
+
+import (
+	"fmt"
+	"os"
+
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+	"github.com/paulmach/orb/planar"
+)
+
+const timeZoneProperty = "tzid"
+
+// TimeZone goes through all polygons and returns the time zone in which the
+// point is located. Country code is not used, time zone boundaries follow
+// the borders already.
+//
+// Returns locodedb.ErrTimeZoneNotFound if no entry matches.
+//
+// All GeoJSON feature are parsed from file once and stored in memory.
+func (db *DB) TimeZone(_ string, point locodedb.Point) (string, error) {
+	db.once.Do(func() {
+		db.initErr = db.init()
+	})
+
+	if db.initErr != nil {
+		return "", db.initErr
+	}
+
+	planarPoint := orb.Point{float64(point.Longitude), float64(point.Latitude)}
+
+	for _, feature := range db.features {
+		var contains bool
+
+		switch geometry := feature.Geometry.(type) {
+		case orb.MultiPolygon:
+			contains = planar.MultiPolygonContains(geometry, planarPoint)
+		case orb.Polygon:
+			contains = planar.PolygonContains(geometry, planarPoint)
+		}
+
+		if contains {
+			if tz, ok := feature.Properties[timeZoneProperty].(string); ok && tz != "" {
+				return tz, nil
+			}
+		}
+	}
+
+	return "", locode.ErrTimeZoneNotFound
+}
+
+func (db *DB) init() error {
+	data, err := os.ReadFile(db.path)
+	if err != nil {
+		return fmt.Errorf("could not read data file: %w", err)
+	}
+
+	features, err := geojson.UnmarshalFeatureCollection(data)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal GeoJSON feature collection: %w", err)
+	}
+
+	db.features = features.Features
+
+	return nil
+}
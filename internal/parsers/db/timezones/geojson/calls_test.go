@@ -0,0 +1,60 @@
+package timezonesdb
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+const testTimeZones = `{"type": "FeatureCollection", "features": [
+{"type": "Feature", "properties": {"tzid": "Europe/Moscow"}, "geometry": {"type": "Polygon", "coordinates": [[[30, 50], [50, 50], [50, 70], [30, 70], [30, 50]]]}},
+{"type": "Feature", "properties": {"tzid": "Asia/Novosibirsk"}, "geometry": {"type": "MultiPolygon", "coordinates": [[[[75, 50], [85, 50], [85, 60], [75, 60], [75, 50]]], [[[90, 50], [95, 50], [95, 55], [90, 55], [90, 50]]]]}},
+{"type": "Feature", "properties": {}, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]]]}}
+]}`
+
+func TestTimeZone(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "timezones.geojson")
+	if err := os.WriteFile(p, []byte(testTimeZones), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	db := New(Prm{Path: p})
+
+	testCases := []struct {
+		name    string
+		point   locodedb.Point
+		want    string
+		wantErr error
+	}{
+		{name: "polygon", point: locodedb.Point{Latitude: 55.75, Longitude: 37.62}, want: "Europe/Moscow"},
+		{name: "multipolygon", point: locodedb.Point{Latitude: 55.03, Longitude: 82.92}, want: "Asia/Novosibirsk"},
+		{name: "second polygon", point: locodedb.Point{Latitude: 52, Longitude: 92}, want: "Asia/Novosibirsk"},
+		{name: "no time zone", point: locodedb.Point{Latitude: 5, Longitude: 5}, wantErr: locode.ErrTimeZoneNotFound},
+		{name: "outside", point: locodedb.Point{Latitude: -30, Longitude: -60}, wantErr: locode.ErrTimeZoneNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := db.TimeZone("RU", tc.point)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got time zone %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeZoneInitError(t *testing.T) {
+	db := New(Prm{Path: filepath.Join(t.TempDir(), "missing.geojson")})
+
+	// Initialization error is returned by every call, not only the first one.
+	for range 2 {
+		if _, err := db.TimeZone("RU", locodedb.Point{}); err == nil || errors.Is(err, locode.ErrTimeZoneNotFound) {
+			t.Fatalf("got error %v, want initialization error", err)
+		}
+	}
+}
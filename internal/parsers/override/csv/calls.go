@@ -0,0 +1,225 @@
+package csvoverride
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+// Patch applies the overrides to the UN/LOCODE table record. It returns false
+// if the record is deleted.
+//
+// The table is parsed from file once and stored in memory.
+func (db *DB) Patch(r *locode.Record) (bool, error) {
+	if err := db.init(); err != nil {
+		return false, err
+	}
+
+	lc, err := locodedb.Parse(r.LOCODE[0] + r.LOCODE[1])
+	if err != nil {
+		return false, err
+	}
+
+	db.patched[lc] = struct{}{}
+
+	if i, ok := db.deletes[lc]; ok {
+		db.effective[i] = true
+		return false, nil
+	}
+
+	db.setFields(lc, r)
+
+	return true, nil
+}
+
+// PatchContinent returns the continent of the location with the given
+// UN/LOCODE, c is the continent calculated from coordinates.
+func (db *DB) PatchContinent(lc [2]string, c locodedb.Continent) (locodedb.Continent, error) {
+	if err := db.init(); err != nil {
+		return c, err
+	}
+
+	l, err := locodedb.Parse(lc[0] + lc[1])
+	if err != nil {
+		return c, err
+	}
+
+	i, ok := db.sets[l][FieldContinent]
+	if !ok {
+		return c, nil
+	}
+
+	res := locodedb.ContinentFromString(db.overrides[i].Value)
+	if res != c {
+		db.effective[i] = true
+	}
+
+	return res, nil
+}
+
+// IterateAdded passes the records added by the overrides to f in the table
+// order. LOCODEs already present in UN/LOCODE (patched before) are skipped,
+// their OpAdd overrides are reported by Stale then.
+//
+// Returns f's errors directly.
+func (db *DB) IterateAdded(f func(locode.Record) error) error {
+	if err := db.init(); err != nil {
+		return err
+	}
+
+	for i := range db.overrides {
+		o := db.overrides[i]
+		if o.Op != OpAdd {
+			continue
+		}
+		if _, ok := db.patched[o.LOCODE]; ok {
+			continue
+		}
+
+		r := locode.Record{
+			LOCODE:           [2]string{o.LOCODE.Country(), o.LOCODE.Location()},
+			Name:             o.Value,
+			NameWoDiacritics: o.Value,
+		}
+		db.setFields(o.LOCODE, &r)
+		db.effective[i] = true
+
+		if err := f(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stale returns overrides that didn't change anything, they should be
+// removed if UN/LOCODE has been fixed. It must be called after all records
+// are patched and added.
+func (db *DB) Stale() []Override {
+	var res []Override
+
+	for i := range db.overrides {
+		if !db.effective[i] {
+			res = append(res, db.overrides[i])
+		}
+	}
+
+	return res
+}
+
+func (db *DB) setFields(lc locodedb.LOCODE, r *locode.Record) {
+	sets := db.sets[lc]
+	for _, f := range recordFields {
+		i, ok := sets[f]
+		if !ok {
+			continue
+		}
+		if setField(r, f, db.overrides[i].Value) {
+			db.effective[i] = true
+		}
+	}
+}
+
+func (db *DB) init() error {
+	db.once.Do(func() {
+		db.initErr = db.load()
+		if db.initErr != nil {
+			db.initErr = fmt.Errorf("%s: %w", db.path, db.initErr)
+		}
+	})
+
+	return db.initErr
+}
+
+func (db *DB) load() error {
+	file, err := os.OpenFile(db.path, os.O_RDONLY, db.mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	db.sets = make(map[locodedb.LOCODE]map[Field]int)
+	db.adds = make(map[locodedb.LOCODE]int)
+	db.deletes = make(map[locodedb.LOCODE]int)
+	db.patched = make(map[locodedb.LOCODE]struct{})
+
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = overrideFldNum
+
+	words, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("could not read header: %w", err)
+	}
+	if !slices.Equal(words, header) {
+		return fmt.Errorf("%w: unexpected header %v", errInvalidOverride, words)
+	}
+
+	for {
+		words, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return err
+		}
+
+		line, _ := r.FieldPos(0)
+
+		o, err := overrideFromWords(words)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		o.Line = line
+
+		if err := db.add(o); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	db.effective = make([]bool, len(db.overrides))
+
+	return nil
+}
+
+// add checks the override against the previous ones and adds it to the DB.
+func (db *DB) add(o Override) error {
+	_, deleted := db.deletes[o.LOCODE]
+	_, added := db.adds[o.LOCODE]
+
+	i := len(db.overrides)
+
+	switch o.Op {
+	case OpDelete:
+		if deleted || added || len(db.sets[o.LOCODE]) != 0 {
+			return fmt.Errorf("%w: %s is deleted and overridden", errInvalidOverride, o.LOCODE)
+		}
+		db.deletes[o.LOCODE] = i
+	case OpAdd:
+		if deleted || added {
+			return fmt.Errorf("%w: %s is added twice or deleted", errInvalidOverride, o.LOCODE)
+		}
+		db.adds[o.LOCODE] = i
+	case OpSet:
+		if deleted {
+			return fmt.Errorf("%w: %s is deleted and overridden", errInvalidOverride, o.LOCODE)
+		}
+		if _, ok := db.sets[o.LOCODE][o.Field]; ok {
+			return fmt.Errorf("%w: %s %s is set twice", errInvalidOverride, o.LOCODE, o.Field)
+		}
+		if db.sets[o.LOCODE] == nil {
+			db.sets[o.LOCODE] = make(map[Field]int)
+		}
+		db.sets[o.LOCODE][o.Field] = i
+	}
+
+	db.overrides = append(db.overrides, o)
+
+	return nil
+}
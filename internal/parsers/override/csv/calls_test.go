@@ -0,0 +1,272 @@
+package csvoverride
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+const testHeader = "op,locode,field,value,comment\n"
+
+func newTestDB(t *testing.T, data string) *DB {
+	t.Helper()
+
+	p := filepath.Join(t.TempDir(), "override.csv")
+	if err := os.WriteFile(p, []byte(data), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return New(Prm{Path: p})
+}
+
+// staleLines returns lines of the stale overrides.
+func staleLines(db *DB) []int {
+	var res []int
+	for _, o := range db.Stale() {
+		res = append(res, o.Line)
+	}
+	return res
+}
+
+func TestPatch(t *testing.T) {
+	testCases := []struct {
+		name      string
+		overrides string
+		record    locode.Record
+		wantKeep  bool
+		want      locode.Record
+		wantStale []int
+	}{
+		{
+			name:      "set coordinates",
+			overrides: "set,SASAL,coordinates,2444N 05045E,Wrong coordinates\n",
+			record:    locode.Record{LOCODE: [2]string{"SA", "SAL"}, Name: "Salwá", NameWoDiacritics: "Salwa", Coordinates: "2444N 05000E"},
+			wantKeep:  true,
+			want:      locode.Record{LOCODE: [2]string{"SA", "SAL"}, Name: "Salwá", NameWoDiacritics: "Salwa", Coordinates: "2444N 05045E"},
+		},
+		{
+			name:      "set name",
+			overrides: "set,RUMOW,name,Moskva,Wrong name\n",
+			record:    locode.Record{LOCODE: [2]string{"RU", "MOW"}, Name: "Moskwa", NameWoDiacritics: "Moskwa"},
+			wantKeep:  true,
+			want:      locode.Record{LOCODE: [2]string{"RU", "MOW"}, Name: "Moskva", NameWoDiacritics: "Moskva"},
+		},
+		{
+			name:      "set same name",
+			overrides: "set,RUMOW,name,Moskva,Fixed upstream\n",
+			record:    locode.Record{LOCODE: [2]string{"RU", "MOW"}, Name: "Moskva", NameWoDiacritics: "Moskva"},
+			wantKeep:  true,
+			want:      locode.Record{LOCODE: [2]string{"RU", "MOW"}, Name: "Moskva", NameWoDiacritics: "Moskva"},
+			wantStale: []int{2},
+		},
+		{
+			name:      "set native name",
+			overrides: "set,RULED,native_name,Санкт-Петербург,Native name\n",
+			record:    locode.Record{LOCODE: [2]string{"RU", "LED"}, Name: "Sankt-Peterburg", NameWoDiacritics: "Sankt-Peterburg"},
+			wantKeep:  true,
+			want:      locode.Record{LOCODE: [2]string{"RU", "LED"}, Name: "Санкт-Петербург", NameWoDiacritics: "Sankt-Peterburg"},
+		},
+		{
+			name:      "other LOCODE",
+			overrides: "set,SASAL,coordinates,2444N 05045E,Wrong coordinates\n",
+			record:    locode.Record{LOCODE: [2]string{"RU", "MOW"}, Name: "Moskva", NameWoDiacritics: "Moskva"},
+			wantKeep:  true,
+			want:      locode.Record{LOCODE: [2]string{"RU", "MOW"}, Name: "Moskva", NameWoDiacritics: "Moskva"},
+			wantStale: []int{2},
+		},
+		{
+			name:      "delete",
+			overrides: "delete,RUOLD,,,Closed\n",
+			record:    locode.Record{LOCODE: [2]string{"RU", "OLD"}, Name: "Old", NameWoDiacritics: "Old"},
+			want:      locode.Record{LOCODE: [2]string{"RU", "OLD"}, Name: "Old", NameWoDiacritics: "Old"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newTestDB(t, testHeader+tc.overrides)
+
+			r := tc.record
+			keep, err := db.Patch(&r)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if keep != tc.wantKeep {
+				t.Errorf("got keep %t, want %t", keep, tc.wantKeep)
+			}
+			if r != tc.want {
+				t.Errorf("got record %+v, want %+v", r, tc.want)
+			}
+			if got := staleLines(db); !slices.Equal(got, tc.wantStale) {
+				t.Errorf("got stale overrides at lines %v, want %v", got, tc.wantStale)
+			}
+		})
+	}
+}
+
+func TestPatchContinent(t *testing.T) {
+	testCases := []struct {
+		name      string
+		overrides string
+		continent locodedb.Continent
+		want      locodedb.Continent
+		wantStale []int
+	}{
+		{
+			name:      "set continent",
+			overrides: "set,RULED,continent,Asia,Wrong continent\n",
+			continent: locodedb.ContinentEurope,
+			want:      locodedb.ContinentAsia,
+		},
+		{
+			name:      "set unknown continent",
+			overrides: "set,RULED,continent,Europe,No coordinates\n",
+			continent: locodedb.ContinentUnknown,
+			want:      locodedb.ContinentEurope,
+		},
+		{
+			name:      "set same continent",
+			overrides: "set,RULED,continent,Europe,Fixed upstream\n",
+			continent: locodedb.ContinentEurope,
+			want:      locodedb.ContinentEurope,
+			wantStale: []int{2},
+		},
+		{
+			name:      "no continent override",
+			overrides: "set,RULED,native_name,Санкт-Петербург,Native name\n",
+			continent: locodedb.ContinentEurope,
+			want:      locodedb.ContinentEurope,
+			wantStale: []int{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newTestDB(t, testHeader+tc.overrides)
+
+			got, err := db.PatchContinent([2]string{"RU", "LED"}, tc.continent)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got continent %v, want %v", got, tc.want)
+			}
+			if got := staleLines(db); !slices.Equal(got, tc.wantStale) {
+				t.Errorf("got stale overrides at lines %v, want %v", got, tc.wantStale)
+			}
+		})
+	}
+}
+
+func TestIterateAdded(t *testing.T) {
+	testCases := []struct {
+		name      string
+		overrides string
+		// patched is patched before IterateAdded like UN/LOCODE table
+		// records.
+		patched   []locode.Record
+		want      []locode.Record
+		wantStale []int
+	}{
+		{
+			name:      "add",
+			overrides: "add,RU9AB,name,Private depot,Private location\n",
+			want: []locode.Record{
+				{LOCODE: [2]string{"RU", "9AB"}, Name: "Private depot", NameWoDiacritics: "Private depot"},
+			},
+		},
+		{
+			name:      "add with fields",
+			overrides: "add,RU9AB,name,Private depot,Private location\nset,RU9AB,subdiv,MOW,Private location\n",
+			want: []locode.Record{
+				{LOCODE: [2]string{"RU", "9AB"}, Name: "Private depot", NameWoDiacritics: "Private depot", SubDiv: "MOW"},
+			},
+		},
+		{
+			name:      "added upstream",
+			overrides: "add,RUZZZ,name,Nowhere,Added upstream\n",
+			patched: []locode.Record{
+				{LOCODE: [2]string{"RU", "ZZZ"}, Name: "Nowhere", NameWoDiacritics: "Nowhere"},
+			},
+			wantStale: []int{2},
+		},
+		{
+			name:      "other LOCODE patched",
+			overrides: "add,RU9AB,name,Private depot,Private location\n",
+			patched: []locode.Record{
+				{LOCODE: [2]string{"RU", "ZZZ"}, Name: "Nowhere", NameWoDiacritics: "Nowhere"},
+			},
+			want: []locode.Record{
+				{LOCODE: [2]string{"RU", "9AB"}, Name: "Private depot", NameWoDiacritics: "Private depot"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newTestDB(t, testHeader+tc.overrides)
+
+			for _, r := range tc.patched {
+				if _, err := db.Patch(&r); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			var got []locode.Record
+			if err := db.IterateAdded(func(r locode.Record) error {
+				got = append(got, r)
+				return nil
+			}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got added records %+v, want %+v", got, tc.want)
+			}
+			if got := staleLines(db); !slices.Equal(got, tc.wantStale) {
+				t.Errorf("got stale overrides at lines %v, want %v", got, tc.wantStale)
+			}
+		})
+	}
+}
+
+func TestInvalidOverrides(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "no header", data: "set,SASAL,coordinates,2444N 05045E,Comment\n"},
+		{name: "no comment", data: testHeader + "set,SASAL,coordinates,2444N 05045E, \n"},
+		{name: "invalid LOCODE", data: testHeader + "set,SA-SAL,coordinates,2444N 05045E,Comment\n"},
+		{name: "unknown operation", data: testHeader + "replace,SASAL,coordinates,2444N 05045E,Comment\n"},
+		{name: "unknown field", data: testHeader + "set,SASAL,population,1000,Comment\n"},
+		{name: "invalid coordinates", data: testHeader + "set,SASAL,coordinates,2444N,Comment\n"},
+		{name: "invalid function", data: testHeader + "set,SASAL,function,3,Comment\n"},
+		{name: "invalid status", data: testHeader + "set,SASAL,status,ZZ,Comment\n"},
+		{name: "short IATA", data: testHeader + "set,SASAL,iata,AB,Comment\n"},
+		{name: "lowercase IATA", data: testHeader + "set,SASAL,iata,abc,Comment\n"},
+		{name: "long name", data: testHeader + "set,SASAL,name," + strings.Repeat("x", 256) + ",Comment\n"},
+		{name: "long native name", data: testHeader + "set,SASAL,native_name," + strings.Repeat("x", 256) + ",Comment\n"},
+		{name: "long subdivision", data: testHeader + "set,SASAL,subdiv," + strings.Repeat("x", 256) + ",Comment\n"},
+		{name: "add with long name", data: testHeader + "add,RU9AB,name," + strings.Repeat("x", 256) + ",Comment\n"},
+		{name: "invalid continent", data: testHeader + "set,SASAL,continent,Atlantis,Comment\n"},
+		{name: "add without name", data: testHeader + "add,SASAL,subdiv,04,Comment\n"},
+		{name: "delete with value", data: testHeader + "delete,SASAL,name,Salwa,Comment\n"},
+		{name: "set twice", data: testHeader + "set,SASAL,name,Salwa,Comment\nset,SASAL,name,Salva,Comment\n"},
+		{name: "delete and set", data: testHeader + "set,SASAL,name,Salwa,Comment\ndelete,SASAL,,,Comment\n"},
+		{name: "set deleted", data: testHeader + "delete,SASAL,,,Comment\nset,SASAL,name,Salwa,Comment\n"},
+		{name: "add twice", data: testHeader + "add,RU9AB,name,Depot,Comment\nadd,RU9AB,name,Depot,Comment\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newTestDB(t, tc.data).Patch(&locode.Record{LOCODE: [2]string{"SA", "SAL"}})
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			if !errors.Is(err, errInvalidOverride) {
+				t.Errorf("got error %v, want %v", err, errInvalidOverride)
+			}
+		})
+	}
+}
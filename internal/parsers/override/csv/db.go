@@ -0,0 +1,83 @@
+package csvoverride
+
+import (
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+// Prm groups the required parameters of the DB's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Path to the override table in CSV format.
+	//
+	// Must not be empty.
+	Path string
+}
+
+// DB is a descriptor of the local UN/LOCODE override table in CSV format.
+//
+// The table consists of "op,locode,field,value,comment" records (the first
+// one is a header), see Op for supported operations and Field for fields
+// that can be overridden. The comment justifying the override is required.
+//
+// For correct operation, DB must be created
+// using the constructor (New) based on the required parameters
+// and optional components. After successful creation,
+// The DB is immediately ready to work through API.
+type DB struct {
+	path string
+
+	mode fs.FileMode
+
+	once sync.Once
+
+	initErr error
+
+	overrides []Override
+
+	// effective marks overrides that changed anything.
+	effective []bool
+
+	// sets maps LOCODE and field to the index of the override.
+	sets map[locodedb.LOCODE]map[Field]int
+
+	adds map[locodedb.LOCODE]int
+
+	deletes map[locodedb.LOCODE]int
+
+	// patched contains LOCODEs of the patched table records.
+	patched map[locodedb.LOCODE]struct{}
+}
+
+func panicOnPrmValue(n string, v any) {
+	panic(fmt.Sprintf("invalid parameter %s (%T):%v", n, v, v))
+}
+
+// New creates a new instance of the DB.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created DB does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm, opts ...Option) *DB {
+	if prm.Path == "" {
+		panicOnPrmValue("Path", prm.Path)
+	}
+
+	o := defaultOpts()
+
+	for i := range opts {
+		opts[i](o)
+	}
+
+	return &DB{
+		path: prm.Path,
+		mode: o.mode,
+	}
+}
@@ -0,0 +1,18 @@
+package csvoverride
+
+import (
+	"io/fs"
+)
+
+// Option sets an optional parameter of DB.
+type Option func(*options)
+
+type options struct {
+	mode fs.FileMode
+}
+
+func defaultOpts() *options {
+	return &options{
+		mode: 0700,
+	}
+}
@@ -0,0 +1,230 @@
+package csvoverride
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+// Op is an operation of the override.
+type Op string
+
+const (
+	// OpSet replaces the field of the UN/LOCODE entry (either from the
+	// table or added) with the value.
+	OpSet Op = "set"
+
+	// OpAdd adds the private LOCODE missing in UN/LOCODE, the field must be
+	// FieldName. Other fields are set with OpSet.
+	OpAdd Op = "add"
+
+	// OpDelete deletes the UN/LOCODE entry, the field and the value must be
+	// empty.
+	OpDelete Op = "delete"
+)
+
+// Field is a field of the UN/LOCODE entry that can be overridden.
+type Field string
+
+const (
+	// FieldName is the location name. Both ASCII and native names are set
+	// to the value, use FieldNativeName to set a different native name.
+	FieldName Field = "name"
+
+	// FieldNativeName is the location name with diacritic signs.
+	FieldNativeName Field = "native_name"
+
+	// FieldSubDiv is the subdivision code.
+	FieldSubDiv Field = "subdiv"
+
+	// FieldFunction is the function classifier (like "1234----").
+	FieldFunction Field = "function"
+
+	// FieldStatus is the status code (like "AI").
+	FieldStatus Field = "status"
+
+	// FieldIATA is the IATA code.
+	FieldIATA Field = "iata"
+
+	// FieldCoordinates are coordinates in UN/LOCODE format (like
+	// "5545N 03737E"). Point source of the location is "override" then.
+	FieldCoordinates Field = "coordinates"
+
+	// FieldContinent is the continent name (like "Europe"), it replaces the
+	// continent calculated from coordinates.
+	FieldContinent Field = "continent"
+)
+
+// recordFields are fields of locode.Record in the order of application.
+var recordFields = []Field{
+	FieldName,
+	FieldNativeName,
+	FieldSubDiv,
+	FieldFunction,
+	FieldStatus,
+	FieldIATA,
+	FieldCoordinates,
+}
+
+// Override is a record of the override table.
+type Override struct {
+	// Line is a line number of the record in the table.
+	Line int
+
+	Op Op
+
+	LOCODE locodedb.LOCODE
+
+	// Field is empty for OpDelete.
+	Field Field
+
+	// Value is empty for OpDelete.
+	Value string
+
+	// Comment is a justification of the override.
+	Comment string
+}
+
+// String returns a string representation of the Override for logs.
+func (o Override) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "line %d: %s %s", o.Line, o.Op, o.LOCODE)
+	if o.Field != "" {
+		fmt.Fprintf(&sb, " %s=%q", o.Field, o.Value)
+	}
+	fmt.Fprintf(&sb, " (%s)", o.Comment)
+	return sb.String()
+}
+
+var errInvalidOverride = errors.New("invalid override")
+
+// header is the required first record of the table.
+var header = []string{"op", "locode", "field", "value", "comment"}
+
+const (
+	overrideOp = iota
+	overrideLOCODE
+	overrideField
+	overrideValue
+	overrideComment
+
+	overrideFldNum
+)
+
+func overrideFromWords(words []string) (Override, error) {
+	var (
+		o = Override{
+			Op:      Op(words[overrideOp]),
+			Field:   Field(words[overrideField]),
+			Value:   words[overrideValue],
+			Comment: strings.TrimSpace(words[overrideComment]),
+		}
+		err error
+	)
+
+	if o.Comment == "" {
+		return o, fmt.Errorf("%w: comment is required", errInvalidOverride)
+	}
+
+	o.LOCODE, err = locodedb.Parse(words[overrideLOCODE])
+	if err != nil {
+		return o, fmt.Errorf("%w: LOCODE %q: %w", errInvalidOverride, words[overrideLOCODE], err)
+	}
+
+	switch o.Op {
+	default:
+		return o, fmt.Errorf("%w: unknown operation %q", errInvalidOverride, o.Op)
+	case OpAdd:
+		if o.Field != FieldName || o.Value == "" {
+			return o, fmt.Errorf("%w: name is required for %s", errInvalidOverride, o.Op)
+		}
+		if err := validateValue(o.Field, o.Value); err != nil {
+			return o, fmt.Errorf("%w: %s: %w", errInvalidOverride, o.Field, err)
+		}
+	case OpDelete:
+		if o.Field != "" || o.Value != "" {
+			return o, fmt.Errorf("%w: field and value must be empty for %s", errInvalidOverride, o.Op)
+		}
+	case OpSet:
+		if err := validateValue(o.Field, o.Value); err != nil {
+			return o, fmt.Errorf("%w: %s: %w", errInvalidOverride, o.Field, err)
+		}
+	}
+
+	return o, nil
+}
+
+func validateValue(f Field, v string) error {
+	var err error
+
+	switch f {
+	default:
+		return errors.New("unknown field")
+	case FieldName:
+		if v == "" {
+			return errors.New("empty name")
+		}
+		err = checkLen(v)
+	case FieldNativeName, FieldSubDiv:
+		err = checkLen(v)
+	case FieldIATA:
+		if !locode.IsIATA(v) {
+			err = errors.New("3 uppercase letters or digits are expected")
+		}
+	case FieldFunction:
+		_, err = locodedb.FunctionsFromString(v)
+	case FieldStatus:
+		_, err = locodedb.StatusFromString(v)
+	case FieldCoordinates:
+		_, err = locode.CoordinatesFromString(v)
+	case FieldContinent:
+		if locodedb.ContinentFromString(v) == locodedb.ContinentUnknown {
+			err = locodedb.ErrInvalidString
+		}
+	}
+
+	return err
+}
+
+// checkLen checks that the value fits into the locode data record string.
+func checkLen(v string) error {
+	if len(v) > math.MaxUint8 {
+		return fmt.Errorf("%d bytes long, at most %d bytes are allowed", len(v), math.MaxUint8)
+	}
+	return nil
+}
+
+// setField sets the field of the table record and returns true if it has
+// been changed.
+func setField(r *locode.Record, f Field, v string) bool {
+	var p *string
+
+	switch f {
+	case FieldName:
+		changed := r.NameWoDiacritics != v || r.Name != v
+		r.NameWoDiacritics, r.Name = v, v
+		return changed
+	case FieldNativeName:
+		p = &r.Name
+	case FieldSubDiv:
+		p = &r.SubDiv
+	case FieldFunction:
+		p = &r.Function
+	case FieldStatus:
+		p = &r.Status
+	case FieldIATA:
+		p = &r.IATA
+	case FieldCoordinates:
+		p = &r.Coordinates
+	default:
+		return false
+	}
+
+	changed := *p != v
+	*p = v
+	return changed
+}
@@ -3,18 +3,23 @@ package csvlocode
 import (
 	"encoding/csv"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strings"
 	"unicode/utf8"
 
 	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
 	"golang.org/x/text/encoding/charmap"
 )
 
 var errInvalidSubName = errors.New("could not convert subDivName to uft-8 valid string")
 var errInvalidRecord = errors.New("invalid table record")
 
+// statusLen is the length of UN/LOCODE entry status code.
+const statusLen = 2
+
 // IterateAll scans a table record one-by-one, parses a UN/LOCODE record
 // from it and passes it to f.
 //
@@ -25,13 +30,27 @@ func (t *Table) IterateAll(f func(locode.Record) error) error {
 	return t.scanWords(t.paths, wordsPerRecord, func(words []string) error {
 		lc := [2]string{words[1], words[2]}
 
+		// UN/LOCODE tables are distributed in ISO 8859-1.
+		name := words[3]
+		if !utf8.ValidString(name) {
+			name, _ = charmap.ISO8859_1.NewDecoder().String(name)
+		}
+
+		// The order of function and status columns isn't the same in all
+		// UN/LOCODE exports, they are told apart by length.
+		function, status := words[6], words[7]
+		if len(function) == statusLen && len(status) == locodedb.FunctionsLen {
+			function, status = status, function
+		}
+
 		record := locode.Record{
+			Change:           words[0],
 			LOCODE:           lc,
-			Name:             words[3],
+			Name:             name,
 			NameWoDiacritics: words[4],
 			SubDiv:           words[5],
-			Function:         words[6],
-			Status:           words[7],
+			Function:         function,
+			Status:           status,
 			Date:             words[8],
 			IATA:             words[9],
 			Coordinates:      words[10],
@@ -48,11 +67,14 @@ const (
 	subDivCountry
 	subDivSubdivision
 	subDivName
-	_ // subDivLevel
+	subDivType
 
 	subDivFldNum
 )
 
+// subDivNamesFldNum is a number of fields in ISO 3166-2 names table records.
+const subDivNamesFldNum = 2
+
 type subDivKey struct {
 	countryCode,
 	subDivCode string
@@ -60,10 +82,13 @@ type subDivKey struct {
 
 type subDivRecord struct {
 	name string
+	typ  string
 }
 
 // SubDivName scans a table record to an in-memory table (once),
 // and returns the subdivision name of the country and the subdivision codes match.
+// The name is empty if it can't be decoded and is missing in ISO 3166-2 names
+// (see WithSubDivNames).
 //
 // Returns locodedb.ErrSubDivNotFound if no entry matches.
 func (t *Table) SubDivName(countryCode string, code string) (string, error) {
@@ -82,6 +107,27 @@ func (t *Table) SubDivName(countryCode string, code string) (string, error) {
 	return rec.name, nil
 }
 
+// SubDivType scans a table record to an in-memory table (once), and returns
+// the subdivision type (like "Region" or "State") of the country and the
+// subdivision codes match.
+//
+// Returns locodedb.ErrSubDivNotFound if no entry matches.
+func (t *Table) SubDivType(countryCode string, code string) (string, error) {
+	if err := t.initSubDiv(); err != nil {
+		return "", err
+	}
+
+	rec, ok := t.mSubDiv[subDivKey{
+		countryCode: countryCode,
+		subDivCode:  code,
+	}]
+	if !ok {
+		return "", locode.ErrSubDivNotFound
+	}
+
+	return rec.typ, nil
+}
+
 func isValidString(s string) bool {
 	return !strings.Contains(s, "\uFFFD") && !strings.Contains(s, "\u0000") && !strings.Contains(s, "?")
 }
@@ -107,19 +153,49 @@ func (t *Table) initSubDiv() (err error) {
 		err = t.scanWords([]string{t.subDivPath}, subDivFldNum, func(words []string) error {
 			subdiv := words[subDivName]
 			if !utf8.ValidString(subdiv) {
+				var err error
 				subdiv, err = utf8encoding(subdiv)
 				if err != nil {
-					if errors.Is(err, errInvalidSubName) {
-						return nil
+					if !errors.Is(err, errInvalidSubName) {
+						return err
 					}
-					return err
+					// Type and code are still useful, the name can
+					// be taken from ISO 3166-2 names.
+					subdiv = ""
 				}
 			}
+			typ := words[subDivType]
+			if !utf8.ValidString(typ) {
+				typ, _ = charmap.ISO8859_1.NewDecoder().String(typ)
+			}
 			t.mSubDiv[subDivKey{
 				countryCode: words[subDivCountry],
 				subDivCode:  words[subDivSubdivision],
 			}] = subDivRecord{
 				name: subdiv,
+				typ:  typ,
+			}
+
+			return nil
+		})
+		if err != nil || t.subDivNamesPath == "" {
+			return
+		}
+
+		err = t.scanWords([]string{t.subDivNamesPath}, subDivNamesFldNum, func(words []string) error {
+			countryCode, subDivCode, ok := strings.Cut(words[0], "-")
+			if !ok || countryCode == "" || subDivCode == "" {
+				return fmt.Errorf("%w: ISO 3166-2 code expected, got %q", errInvalidRecord, words[0])
+			}
+
+			key := subDivKey{
+				countryCode: countryCode,
+				subDivCode:  subDivCode,
+			}
+			rec := t.mSubDiv[key]
+			if rec.name == "" {
+				rec.name = words[1]
+				t.mSubDiv[key] = rec
 			}
 
 			return nil
@@ -0,0 +1,164 @@
+package csvlocode
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+)
+
+func TestSubDivNames(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		tablePath = filepath.Join(dir, "CodeList.csv")
+		subDiv    = filepath.Join(dir, "SubdivisionCodes.csv")
+		names     = filepath.Join(dir, "names.csv")
+	)
+	for p, data := range map[string]string{
+		tablePath: "",
+		subDiv: "\"RU\",\"MOW\",\"Moskva\",\"Autonomous city\"\n" +
+			"\"RU\",\"MOS\",\"Moskovskaya ?\xff\",\"Region\"\n",
+		names: "RU-MOS,Moskovskaya oblast'\nRU-MOW,Moscow\nRU-SPE,Sankt-Peterburg\n",
+	} {
+		if err := os.WriteFile(p, []byte(data), 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		code     string
+		wantName string
+		wantType string
+		wantErr  error
+	}{
+		{name: "valid", code: "MOW", wantName: "Moskva", wantType: "Autonomous city"},
+		{name: "undecodable", code: "MOS", wantType: "Region"},
+		{name: "missing", code: "SPE", wantErr: locode.ErrSubDivNotFound},
+		{name: "valid with names", opts: []Option{WithSubDivNames(names)}, code: "MOW", wantName: "Moskva", wantType: "Autonomous city"},
+		{name: "undecodable with names", opts: []Option{WithSubDivNames(names)}, code: "MOS", wantName: "Moskovskaya oblast'", wantType: "Region"},
+		{name: "missing with names", opts: []Option{WithSubDivNames(names)}, code: "SPE", wantName: "Sankt-Peterburg"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := New(Prm{Path: tablePath, SubDivPath: subDiv}, tc.opts...)
+
+			name, err := table.SubDivName("RU", tc.code)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if name != tc.wantName {
+				t.Errorf("got name %q, want %q", name, tc.wantName)
+			}
+
+			typ, err := table.SubDivType("RU", tc.code)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if typ != tc.wantType {
+				t.Errorf("got type %q, want %q", typ, tc.wantType)
+			}
+		})
+	}
+}
+
+type testAirports struct{}
+
+func (testAirports) Get(locode.Record) (*locode.AirportRecord, error) {
+	return nil, locode.ErrAirportNotFound
+}
+
+type testContinents struct{}
+
+func (testContinents) PointContinent(locodedb.Point) (*locodedb.Continent, error) {
+	c := locodedb.Continent(locodedb.ContinentEurope)
+	return &c, nil
+}
+
+func TestFillDatabase(t *testing.T) {
+	testCases := []struct {
+		name  string
+		table string
+	}{
+		{
+			// Rows are taken from UN/LOCODE as is.
+			name: "function first",
+			table: `,"AD","",".ANDORRA",".ANDORRA",,,,,,,
+,"AD","ALV","Andorra la Vella","Andorra la Vella","07","--34-6--","AI","0601",,"4230N 00131E",
+`,
+		},
+		{
+			name: "status first",
+			table: `,"AD","",".ANDORRA",".ANDORRA",,,,,,,
+,"AD","ALV","Andorra la Vella","Andorra la Vella","07","AI","--34-6--","0601",,"4230N 00131E",
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				dir       = t.TempDir()
+				out       = t.TempDir()
+				tablePath = filepath.Join(dir, "CodeList.csv")
+				subDiv    = filepath.Join(dir, "SubdivisionCodes.csv")
+				names     = filepath.Join(dir, "CountryCodes.csv")
+				report    locode.Report
+			)
+			for p, data := range map[string]string{
+				tablePath: tc.table,
+				subDiv:    "\"AD\",\"07\",\"Andorra la Vella\",\"Parish\"\n",
+				names:     "\"AD\",\"Andorra\"\n",
+			} {
+				if err := os.WriteFile(p, []byte(data), 0600); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			table := New(Prm{Path: tablePath, SubDivPath: subDiv}, WithCountryNames(names))
+			err := locode.FillDatabase(table, testAirports{}, testContinents{}, table, locode.New(out), locode.WithReport(&report))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(report.Skipped) != 0 || len(report.Warnings) != 0 {
+				t.Errorf("got skipped %v and warnings %v, want none", report.Skipped, report.Warnings)
+			}
+
+			countries, err := os.Open(filepath.Join(out, "countries.csv"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer countries.Close()
+			locodes, err := os.Open(filepath.Join(out, "locodes.csv"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer locodes.Close()
+
+			db, err := locodedb.Open(countries, locodes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			rec, err := db.Get("ADALV")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := rec.Functions.String(); got != "--34-6--" {
+				t.Errorf("got functions %q", got)
+			}
+			if got := rec.Status.String(); got != "AI" {
+				t.Errorf("got status %q", got)
+			}
+			if got := rec.Updated.Format("2006-01"); got != "2006-01" {
+				t.Errorf("got date %s", got)
+			}
+			if rec.Country != "Andorra" || rec.SubDivName != "Andorra la Vella" || rec.SubDivType != "Parish" {
+				t.Errorf("got country %q, subdivision %q (%q)", rec.Country, rec.SubDivName, rec.SubDivType)
+			}
+		})
+	}
+}
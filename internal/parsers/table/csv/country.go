@@ -0,0 +1,49 @@
+package csvlocode
+
+import (
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+)
+
+const (
+	_ = iota - 1
+
+	countryCode
+	countryName
+
+	countryFldNum
+)
+
+// CountryName scans the ISO 3166 country names table (see WithCountryNames)
+// to an in-memory table (once), and returns the country name by code.
+//
+// Returns locodedb.ErrCountryNotFound if no entry matches or the table is not
+// set.
+func (t *Table) CountryName(code string) (string, error) {
+	if err := t.initCountries(); err != nil {
+		return "", err
+	}
+
+	name, ok := t.mCountries[code]
+	if !ok {
+		return "", locode.ErrCountryNotFound
+	}
+
+	return name, nil
+}
+
+func (t *Table) initCountries() (err error) {
+	t.countriesOnce.Do(func() {
+		t.mCountries = make(map[string]string)
+
+		if t.countriesPath == "" {
+			return
+		}
+
+		err = t.scanWords([]string{t.countriesPath}, countryFldNum, func(words []string) error {
+			t.mCountries[words[countryCode]] = words[countryName]
+			return nil
+		})
+	})
+
+	return
+}
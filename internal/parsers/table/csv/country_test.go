@@ -0,0 +1,49 @@
+package csvlocode
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	locode "github.com/nspcc-dev/locode-db/internal/parsers/db"
+)
+
+func TestCountryName(t *testing.T) {
+	dir := t.TempDir()
+	names := filepath.Join(dir, "CountryCodes.csv")
+	err := os.WriteFile(names, []byte(`"BA","Bosnia and Herzegovina"
+"CD","Congo, The Democratic Republic of the"
+"VI","Virgin Islands, U.S."
+`), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		opts    []Option
+		code    string
+		want    string
+		wantErr error
+	}{
+		{name: "simple", opts: []Option{WithCountryNames(names)}, code: "BA", want: "Bosnia and Herzegovina"},
+		{name: "comma", opts: []Option{WithCountryNames(names)}, code: "CD", want: "Congo, The Democratic Republic of the"},
+		{name: "abbreviation", opts: []Option{WithCountryNames(names)}, code: "VI", want: "Virgin Islands, U.S."},
+		{name: "missing", opts: []Option{WithCountryNames(names)}, code: "RU", wantErr: locode.ErrCountryNotFound},
+		{name: "no table", code: "BA", wantErr: locode.ErrCountryNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := New(Prm{Path: names, SubDivPath: names}, tc.opts...)
+
+			got, err := table.CountryName(tc.code)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
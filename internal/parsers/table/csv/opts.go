@@ -11,6 +11,10 @@ type options struct {
 	mode fs.FileMode
 
 	extraPaths []string
+
+	subDivNamesPath string
+
+	countriesPath string
 }
 
 func defaultOpts() *options {
@@ -26,3 +30,21 @@ func WithExtraPaths(ps ...string) Option {
 		o.extraPaths = append(o.extraPaths, ps...)
 	}
 }
+
+// WithSubDivNames returns an option to use the CSV table of ISO 3166-2
+// subdivision names ("CC-SUB,name" records) for subdivisions missing in the
+// UN/LOCODE table or having a name in an unknown encoding there.
+func WithSubDivNames(p string) Option {
+	return func(o *options) {
+		o.subDivNamesPath = p
+	}
+}
+
+// WithCountryNames returns an option to use the CSV table of ISO 3166 country
+// names ("code,name" records, like country-codes.csv of datasets/un-locode).
+// Country names are not known to the Table otherwise.
+func WithCountryNames(p string) Option {
+	return func(o *options) {
+		o.countriesPath = p
+	}
+}
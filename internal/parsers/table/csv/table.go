@@ -36,9 +36,17 @@ type Table struct {
 
 	subDivPath string
 
+	subDivNamesPath string
+
 	subDivOnce sync.Once
 
 	mSubDiv map[subDivKey]subDivRecord
+
+	countriesPath string
+
+	countriesOnce sync.Once
+
+	mCountries map[string]string
 }
 
 const invalidPrmValFmt = "invalid parameter %s (%T):%v"
@@ -71,5 +79,9 @@ func New(prm Prm, opts ...Option) *Table {
 		paths:      append([]string{prm.Path}, o.extraPaths...),
 		mode:       o.mode,
 		subDivPath: prm.SubDivPath,
+
+		subDivNamesPath: o.subDivNamesPath,
+
+		countriesPath: o.countriesPath,
 	}
 }
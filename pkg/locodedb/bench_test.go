@@ -1,6 +1,8 @@
 package locodedb
 
 import (
+	"bytes"
+	"compress/bzip2"
 	_ "embed"
 	"testing"
 
@@ -22,9 +24,10 @@ func BenchmarkUnpack(b *testing.B) {
 	require.NotEmpty(b, testCountriesData)
 	require.NotEmpty(b, testLocodesData)
 	for b.Loop() {
-		m, err := unpackCountriesData(testCountriesData)
-		require.NoError(b, err)
-		_, err = unpackLocodesData(testLocodesData, m)
+		_, err := Open(
+			bzip2.NewReader(bytes.NewReader(testCountriesData)),
+			bzip2.NewReader(bytes.NewReader(testLocodesData)),
+		)
 		require.NoError(b, err)
 	}
 }
@@ -41,3 +44,24 @@ func BenchmarkGet(b *testing.B) {
 		_, _ = Get("JOSAH")
 	}
 }
+
+func BenchmarkNearest(b *testing.B) {
+	_, _, _, err := Nearest(Point{Latitude: 55.75, Longitude: 37.61})
+	require.NoError(b, err)
+	for b.Loop() {
+		_, _, _, _ = Nearest(Point{Latitude: 55.75, Longitude: 37.61})
+		_, _, _, _ = Nearest(Point{Latitude: -17.5, Longitude: 179.99})
+		_, _, _, _ = Nearest(Point{Latitude: -50, Longitude: -120})
+	}
+}
+
+func BenchmarkComplete(b *testing.B) {
+	for range Complete("Ams", 10) {
+	}
+	for b.Loop() {
+		for range Complete("Ams", 10) {
+		}
+		for range Complete("Mosk", 10, WithCountry("RU")) {
+		}
+	}
+}
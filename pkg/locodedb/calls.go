@@ -3,83 +3,131 @@ package locodedb
 import (
 	"cmp"
 	"errors"
+	"iter"
 	"slices"
+	"strings"
 )
 
 // ErrNotFound is returned when the record is not found in the location database.
 var ErrNotFound = errors.New("record not found")
 
-var (
-	// locodeStrings is a string containing all substrings of locode data.
-	locodeStrings string
-
-	// mCountries is a map of country codes to country names and locodes.
-	mCountries map[countryCode]countryData
-)
+// Get returns a record for a given locode string from the default DB. See
+// [DB.Get] for details.
+func Get(locodeStr string) (Record, error) {
+	if err := initLocodeData(); err != nil {
+		return Record{}, err
+	}
+	return defaultDB.Get(locodeStr)
+}
 
 // Get returns a record for a given locode string. The string must be 5 or 6
 // letters long. The first 2 letters are country code followed by an optional
 // space separator and 3 letters of the location code.
-func Get(locodeStr string) (Record, error) {
-	if err := initLocodeData(); err != nil {
+func (db *DB) Get(locodeStr string) (Record, error) {
+	locodeStr, err := normalizeLOCODE(locodeStr)
+	if err != nil {
 		return Record{}, err
 	}
 
-	if len(locodeStr) == CountryCodeLen+LocationCodeLen+1 && locodeStr[CountryCodeLen] == ' ' {
-		locodeStr = locodeStr[:CountryCodeLen] + locodeStr[CountryCodeLen+1:]
-	}
-	if len(locodeStr) != CountryCodeLen+LocationCodeLen {
-		return Record{}, ErrInvalidString
-	}
+	cc := countryCode{}
+	copy(cc[:], locodeStr[:CountryCodeLen])
+	return db.get(cc, locodeStr[CountryCodeLen:])
+}
 
-	for i := range locodeStr[:CountryCodeLen] {
-		if !isUpperAlpha(locodeStr[i]) {
-			return Record{}, ErrInvalidString
-		}
-	}
-	for i := range locodeStr[CountryCodeLen:] {
-		if !isUpperAlpha(locodeStr[CountryCodeLen+i]) && !isDigit(locodeStr[CountryCodeLen+i]) {
-			return Record{}, ErrInvalidString
-		}
+// GetLOCODE returns a record for a given LOCODE from the default DB.
+func GetLOCODE(l LOCODE) (Record, error) {
+	if err := initLocodeData(); err != nil {
+		return Record{}, err
 	}
+	return defaultDB.GetLOCODE(l)
+}
 
-	cc := countryCode{}
-	copy(cc[:], locodeStr[:2])
-	cd, countryFound := mCountries[cc]
+// GetLOCODE returns a record for a given LOCODE. ErrNotFound is returned for
+// zero LOCODE.
+func (db *DB) GetLOCODE(l LOCODE) (Record, error) {
+	return db.get(l.country, string(l.location[:]))
+}
+
+func (db *DB) get(cc countryCode, code string) (Record, error) {
+	cd, countryFound := db.countries[cc]
 	if !countryFound {
 		return Record{}, ErrNotFound
 	}
 
-	code := locodeStr[CountryCodeLen:]
 	n, ok := slices.BinarySearchFunc(cd.locodes, code, func(csv locodesCSV, s string) int {
-		return cmp.Compare(codeFromCSV(&csv), s)
+		return cmp.Compare(db.codeFromCSV(&csv), s)
 	})
 	if !ok {
 		return Record{}, ErrNotFound
 	}
 
-	return Record{
-		Country:    cd.name,
-		Location:   locFromCSV(&cd.locodes[n]),
-		SubDivName: divNameFromCSV(&cd.locodes[n]),
-		SubDivCode: divCodeFromCSV(&cd.locodes[n]),
-		Point:      cd.locodes[n].point,
-		Cont:       cd.locodes[n].continent,
-	}, nil
+	return db.recordFromCSV(&cd, &cd.locodes[n]), nil
 }
 
-func codeFromCSV(c *locodesCSV) string {
-	return locodeStrings[c.offset : c.offset+LocationCodeLen]
+// All returns an iterator over all records of the default DB. If the DB can't
+// be unpacked, the sequence is empty. See [DB.All] for details.
+func All() iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.All()(yield)
+	}
 }
 
-func locFromCSV(c *locodesCSV) string {
-	return locodeStrings[c.offset+LocationCodeLen : c.offset+LocationCodeLen+uint32(c.locationLen)]
+// All returns an iterator over all records of the location database. Records
+// are ordered by LOCODE, keys are LOCODE strings without space separator.
+func (db *DB) All() iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		for _, cc := range db.codes {
+			cd := db.countries[cc]
+			for i := range cd.locodes {
+				if !yield(string(cc[:])+db.codeFromCSV(&cd.locodes[i]), db.recordFromCSV(&cd, &cd.locodes[i])) {
+					return
+				}
+			}
+		}
+	}
 }
 
-func divCodeFromCSV(c *locodesCSV) string {
-	return locodeStrings[c.offset+LocationCodeLen+uint32(c.locationLen) : c.offset+LocationCodeLen+uint32(c.locationLen)+uint32(c.subDivCodeLen)]
+// record returns the LOCODE string without space separator and the record
+// of the location by its country and index.
+func (db *DB) record(cc countryCode, idx uint32) (string, Record) {
+	cd := db.countries[cc]
+	return string(cc[:]) + db.codeFromCSV(&cd.locodes[idx]), db.recordFromCSV(&cd, &cd.locodes[idx])
 }
 
-func divNameFromCSV(c *locodesCSV) string {
-	return locodeStrings[c.offset+LocationCodeLen+uint32(c.locationLen)+uint32(c.subDivCodeLen) : c.offset+LocationCodeLen+uint32(c.locationLen)+uint32(c.subDivCodeLen)+uint32(c.subDivNameLen)]
+func (db *DB) recordFromCSV(cd *countryData, c *locodesCSV) Record {
+	iata := db.iataFromCSV(c)
+	if iata == "" {
+		iata = db.codeFromCSV(c)
+	}
+	location := db.locFromCSV(c)
+	native := db.nativeFromCSV(c)
+	if native == "" {
+		native = location
+	}
+	var aliases []string
+	if c.aliasesLen != 0 {
+		aliases = strings.Split(db.aliasesFromCSV(c), aliasSeparator)
+	}
+	return Record{
+		Country:        cd.name,
+		Location:       location,
+		LocationNative: native,
+		SubDivName:     db.divNameFromCSV(c),
+		SubDivCode:     db.divCodeFromCSV(c),
+		Point:          c.point,
+		Cont:           c.continent,
+		Functions:      c.functions,
+		Status:         c.status,
+		IATA:           iata,
+		Updated:        timeFromMonth(c.updated),
+		Aliases:        aliases,
+		Removed:        c.removed,
+		SubDivType:     db.subDivTypes[c.subDivType],
+		PointSource:    c.pointSource,
+		Accuracy:       float64(c.accuracy) / cmPerKm,
+		TimeZone:       db.timeZones[c.timeZone],
+	}
 }
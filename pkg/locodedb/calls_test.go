@@ -22,7 +22,8 @@ func TestGet(t *testing.T) {
 		rec, err := locodedb.Get("RU MOW")
 		require.NoError(t, err)
 
-		require.Equal(t, rec.Country, "Russia")
+		// OpenFlights ("Russia") or ISO 3166 ("Russian Federation") name.
+		require.Contains(t, rec.Country, "Russia")
 		require.Equal(t, rec.Location, "Moskva")
 		require.Equal(t, rec.SubDivCode, "MOW")
 		require.Equal(t, rec.SubDivName, "Moskva")
@@ -32,7 +33,8 @@ func TestGet(t *testing.T) {
 		rec, err := locodedb.Get("RUMOW")
 		require.NoError(t, err)
 
-		require.Equal(t, rec.Country, "Russia")
+		// OpenFlights ("Russia") or ISO 3166 ("Russian Federation") name.
+		require.Contains(t, rec.Country, "Russia")
 		require.Equal(t, rec.Location, "Moskva")
 		require.Equal(t, rec.SubDivCode, "MOW")
 		require.Equal(t, rec.SubDivName, "Moskva")
@@ -66,3 +68,101 @@ func TestGet(t *testing.T) {
 		})
 	})
 }
+
+func TestAll(t *testing.T) {
+	var (
+		prev string
+		num  int
+	)
+	for code, rec := range locodedb.All() {
+		require.Less(t, prev, code)
+		prev = code
+		num++
+
+		if code == "RUMOW" {
+			exp, err := locodedb.Get(code)
+			require.NoError(t, err)
+			require.Equal(t, exp, rec)
+		}
+	}
+	require.Greater(t, num, 90000)
+
+	t.Run("break", func(t *testing.T) {
+		num = 0
+		for range locodedb.All() {
+			num++
+			if num == 10 {
+				break
+			}
+		}
+		require.Equal(t, 10, num)
+	})
+}
+
+func TestCountries(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		c, err := locodedb.GetCountry("RU")
+		require.NoError(t, err)
+		require.Equal(t, "RU", c.Code)
+		require.Equal(t, "Russia", c.Name)
+		require.Positive(t, c.Locations)
+
+		_, err = locodedb.GetCountry("ru")
+		require.ErrorIs(t, err, locodedb.ErrInvalidString)
+		_, err = locodedb.GetCountry("RUS")
+		require.ErrorIs(t, err, locodedb.ErrInvalidString)
+		_, err = locodedb.GetCountry("ZZ")
+		require.ErrorIs(t, err, locodedb.ErrNotFound)
+	})
+
+	var (
+		prev      string
+		locations int
+	)
+	for c := range locodedb.Countries() {
+		require.Less(t, prev, c.Code)
+		require.NotEmpty(t, c.Name)
+		prev = c.Code
+		locations += c.Locations
+	}
+	var num int
+	for range locodedb.All() {
+		num++
+	}
+	require.Equal(t, num, locations)
+}
+
+func TestSubdivisions(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		sd, err := locodedb.GetSubdivision("RU", "MOW")
+		require.NoError(t, err)
+		require.Equal(t, "MOW", sd.Code)
+		require.Equal(t, "Moskva", sd.Name)
+		require.Positive(t, sd.Locations)
+
+		_, err = locodedb.GetSubdivision("RU", "XXX")
+		require.ErrorIs(t, err, locodedb.ErrNotFound)
+		_, err = locodedb.GetSubdivision("ZZ", "MOW")
+		require.ErrorIs(t, err, locodedb.ErrNotFound)
+		_, err = locodedb.GetSubdivision("R", "MOW")
+		require.ErrorIs(t, err, locodedb.ErrInvalidString)
+	})
+
+	var (
+		prev      string
+		locations int
+	)
+	for sd := range locodedb.Subdivisions("RU") {
+		require.Less(t, prev, sd.Code)
+		prev = sd.Code
+		locations += sd.Locations
+	}
+
+	var num int
+	for code, rec := range locodedb.All() {
+		if code[:2] == "RU" && rec.SubDivCode != "" {
+			num++
+		}
+	}
+	require.Equal(t, num, locations)
+}
@@ -0,0 +1,193 @@
+package locodedb
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// nameIndex is a list of locations sorted by normalized (case- and
+// diacritic-insensitive) name.
+type nameIndex struct {
+	// names is a string containing all normalized names.
+	names   string
+	entries []nameEntry
+	// hasAlternatives is set if some locations have several entries
+	// (ASCII, native names and aliases).
+	hasAlternatives bool
+}
+
+type nameEntry struct {
+	offset  uint32
+	nameLen uint8
+	cc      countryCode
+	idx     uint32
+}
+
+// Complete returns at most n records of the default DB with location name
+// starting with the prefix. If the DB can't be unpacked, the sequence is
+// empty. See [DB.Complete] for details.
+func Complete(prefix string, n int, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.Complete(prefix, n, opts...)(yield)
+	}
+}
+
+// Complete returns an iterator over at most n records with location name
+// (either ASCII, native or alias) starting with the prefix. Matching ignores case
+// and diacritics, records are ordered by the matching name, so shorter names
+// go first. Every record is returned once even if several of its names match. Keys are LOCODE
+// strings without space separator.
+//
+// Name index is built on the first call of Complete, the index of the country
+// is built on the first call with WithCountry option for it.
+func (db *DB) Complete(prefix string, n int, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		var (
+			names *nameIndex
+			o     = newSearchOptions(opts)
+			p     = newFolder().fold(prefix)
+			seen  map[nameEntry]struct{}
+		)
+		if o.hasCountry {
+			names = db.countryNameIndex(o.country)
+		} else {
+			db.namesOnce.Do(func() {
+				db.names = db.newNameIndex(db.codes)
+			})
+			names = db.names
+		}
+
+		i, _ := slices.BinarySearchFunc(names.entries, p, func(e nameEntry, s string) int {
+			return cmp.Compare(names.name(&e), s)
+		})
+		for ; i < len(names.entries) && n > 0; i++ {
+			e := &names.entries[i]
+			if !strings.HasPrefix(names.name(e), p) {
+				return
+			}
+			cd := db.countries[e.cc]
+			c := &cd.locodes[e.idx]
+			if !o.match(e.cc, c) {
+				continue
+			}
+			if names.hasAlternatives {
+				key := nameEntry{cc: e.cc, idx: e.idx}
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				if seen == nil {
+					seen = make(map[nameEntry]struct{})
+				}
+				seen[key] = struct{}{}
+			}
+			if !yield(string(e.cc[:])+db.codeFromCSV(c), db.recordFromCSV(&cd, c)) {
+				return
+			}
+			n--
+		}
+	}
+}
+
+// countryNameIndex returns the name index of the country building it if
+// needed.
+func (db *DB) countryNameIndex(cc countryCode) *nameIndex {
+	if _, ok := db.countries[cc]; !ok {
+		return new(nameIndex)
+	}
+
+	db.countryNamesMtx.Lock()
+	defer db.countryNamesMtx.Unlock()
+
+	idx, ok := db.countryNames[cc]
+	if !ok {
+		if db.countryNames == nil {
+			db.countryNames = make(map[countryCode]*nameIndex)
+		}
+		idx = db.newNameIndex([]countryCode{cc})
+		db.countryNames[cc] = idx
+	}
+	return idx
+}
+
+// newNameIndex returns the name index of locations of the given countries.
+func (db *DB) newNameIndex(codes []countryCode) *nameIndex {
+	var (
+		b   strings.Builder
+		f   = newFolder()
+		idx nameIndex
+		num int
+	)
+	for _, cc := range codes {
+		num += len(db.countries[cc].locodes)
+	}
+
+	idx.entries = make([]nameEntry, 0, num)
+	add := func(cc countryCode, i int, name string) {
+		name = truncateName(name, math.MaxUint8)
+		idx.entries = append(idx.entries, nameEntry{
+			offset:  uint32(b.Len()),
+			nameLen: uint8(len(name)),
+			cc:      cc,
+			idx:     uint32(i),
+		})
+		b.WriteString(name)
+	}
+	for _, cc := range codes {
+		cd := db.countries[cc]
+		for i := range cd.locodes {
+			name := f.fold(db.locFromCSV(&cd.locodes[i]))
+			add(cc, i, name)
+
+			alternatives := []string{name}
+			if native := db.nativeFromCSV(&cd.locodes[i]); native != "" {
+				alternatives = append(alternatives, native)
+			}
+			if aliases := db.aliasesFromCSV(&cd.locodes[i]); aliases != "" {
+				alternatives = append(alternatives, strings.Split(aliases, aliasSeparator)...)
+			}
+			for j := 1; j < len(alternatives); j++ {
+				alt := f.fold(alternatives[j])
+				if slices.Contains(alternatives[:j], alt) {
+					continue
+				}
+				alternatives[j] = alt
+				add(cc, i, alt)
+				idx.hasAlternatives = true
+			}
+		}
+	}
+	idx.names = b.String()
+
+	slices.SortFunc(idx.entries, func(a, b nameEntry) int {
+		if c := cmp.Compare(idx.name(&a), idx.name(&b)); c != 0 {
+			return c
+		}
+		if c := compareCountryCodes(a.cc, b.cc); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.idx, b.idx)
+	})
+	return &idx
+}
+
+func (idx *nameIndex) name(e *nameEntry) string {
+	return idx.names[e.offset : e.offset+uint32(e.nameLen)]
+}
+
+// truncateName cuts the name to at most n bytes at a character boundary.
+func truncateName(name string, n int) string {
+	if len(name) <= n {
+		return name
+	}
+	for n > 0 && !utf8.RuneStart(name[n]) {
+		n--
+	}
+	return name[:n]
+}
@@ -0,0 +1,27 @@
+package locodedb
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateName(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		n    int
+		exp  string
+	}{
+		{"Moskva", 10, "Moskva"},
+		{"Moskva", 3, "Mos"},
+		{"Москва", 3, "М"},
+		{"Москва", 4, "Мо"},
+		{strings.Repeat("ŉ", 200), 255, strings.Repeat("ŉ", 127)},
+	} {
+		res := truncateName(tc.name, tc.n)
+		require.Equal(t, tc.exp, res)
+		require.True(t, utf8.ValidString(res))
+	}
+}
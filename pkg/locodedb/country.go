@@ -1,8 +1,10 @@
 package locodedb
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"iter"
 )
 
 // CountryCodeLen is the length of the country code.
@@ -14,6 +16,72 @@ const LocationCodeLen = 3
 // ErrInvalidString is returned when the string is not a valid location code.
 var ErrInvalidString = errors.New("invalid string format in UN/Locode")
 
+// Country represents a country of the location database.
+type Country struct {
+	// Code is ISO 3166 alpha-2 country code.
+	Code string
+	// Name is a full country name.
+	Name string
+	// Locations is the number of LOCODEs in the country.
+	Locations int
+}
+
+// GetCountry returns a country for a given ISO 3166 alpha-2 code from the
+// default DB.
+func GetCountry(code string) (Country, error) {
+	if err := initLocodeData(); err != nil {
+		return Country{}, err
+	}
+	return defaultDB.GetCountry(code)
+}
+
+// GetCountry returns a country for a given ISO 3166 alpha-2 code.
+func (db *DB) GetCountry(code string) (Country, error) {
+	cc, err := countryCodeFromString(code)
+	if err != nil {
+		return Country{}, ErrInvalidString
+	}
+
+	cd, ok := db.countries[*cc]
+	if !ok {
+		return Country{}, ErrNotFound
+	}
+
+	return countryFromData(*cc, &cd), nil
+}
+
+// Countries returns an iterator over all countries of the default DB. If the
+// DB can't be unpacked, the sequence is empty.
+func Countries() iter.Seq[Country] {
+	return func(yield func(Country) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.Countries()(yield)
+	}
+}
+
+// Countries returns an iterator over all countries of the location database
+// ordered by code.
+func (db *DB) Countries() iter.Seq[Country] {
+	return func(yield func(Country) bool) {
+		for _, cc := range db.codes {
+			cd := db.countries[cc]
+			if !yield(countryFromData(cc, &cd)) {
+				return
+			}
+		}
+	}
+}
+
+func countryFromData(cc countryCode, cd *countryData) Country {
+	return Country{
+		Code:      string(cc[:]),
+		Name:      cd.name,
+		Locations: len(cd.locodes),
+	}
+}
+
 // countryCode represents ISO 3166 alpha-2 Country Code.
 type countryCode [CountryCodeLen]uint8
 
@@ -38,6 +106,10 @@ func countryCodeFromString(s string) (*countryCode, error) {
 	return &cc, nil
 }
 
+func compareCountryCodes(a, b countryCode) int {
+	return bytes.Compare(a[:], b[:])
+}
+
 func isUpperAlpha(sym uint8) bool {
 	return sym >= 'A' && sym <= 'Z'
 }
@@ -0,0 +1,454 @@
+package locodedb
+
+import (
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DB is a location database. Package-level functions use the default DB
+// embedded into the package, DB can be used to work with other data sets.
+//
+// DB must be created with [Open], it's safe for concurrent use.
+type DB struct {
+	// strings is a string containing all substrings of locode data.
+	strings string
+
+	// countries is a map of country codes to country names and locodes.
+	countries map[countryCode]countryData
+
+	// codes are the keys of countries in sorted order.
+	codes []countryCode
+
+	// subDivTypes is a table of subdivision types referenced by index,
+	// the first one is always empty.
+	subDivTypes []string
+
+	// timeZones is a table of time zones referenced by index, the first one
+	// is always empty.
+	timeZones []string
+
+	subDivsOnce sync.Once
+	// subDivs is a map of country codes to subdivisions sorted by code.
+	subDivs map[countryCode][]Subdivision
+
+	spatialOnce sync.Once
+	spatial     *spatialIndex
+
+	namesOnce sync.Once
+	names     *nameIndex
+
+	countryNamesMtx sync.Mutex
+	// countryNames is a map of country codes to name indexes of their
+	// locations, built on demand.
+	countryNames map[countryCode]*nameIndex
+
+	iataOnce sync.Once
+	// iata is a list of locations sorted by IATA code.
+	iata []iataEntry
+}
+
+type countryData struct {
+	name    string
+	locodes []locodesCSV
+}
+
+type locodesCSV struct {
+	point         Point
+	offset        uint32
+	locationLen   uint8
+	subDivCodeLen uint8
+	subDivNameLen uint8
+	iataLen       uint8
+	nativeLen     uint8
+	aliasesLen    uint8
+	continent     Continent
+	functions     Functions
+	status        Status
+	// updated is the month of the last change, see monthFromTime.
+	updated uint16
+	removed bool
+	// subDivType is an index in DB.subDivTypes.
+	subDivType  uint8
+	pointSource PointSource
+	// accuracy is the accuracy radius of the point in centimeters.
+	accuracy uint32
+	// timeZone is an index in DB.timeZones.
+	timeZone uint16
+}
+
+// Open reads the location database from countries and locodes tables in CSV
+// format (the same as generated files of this package, use compress/bzip2 to
+// read compressed ones).
+func Open(countries, locodes io.Reader) (*DB, error) {
+	mc, err := unpackCountriesData(countries)
+	if err != nil {
+		return nil, fmt.Errorf("countries: %w", err)
+	}
+	db := &DB{
+		countries: mc,
+		codes:     slices.SortedFunc(maps.Keys(mc), compareCountryCodes),
+	}
+	if err := db.unpackLocodesData(locodes); err != nil {
+		return nil, fmt.Errorf("locodes: %w", err)
+	}
+	return db, nil
+}
+
+const (
+	countriesFldNum = 2
+
+	// updatedLayout is the format of the last change date column.
+	updatedLayout = "2006-01"
+
+	// removedMark is the value of the removed column for entries marked for
+	// removal.
+	removedMark = "X"
+
+	// aliasSeparator separates alternative names in the aliases column.
+	aliasSeparator = "|"
+
+	// cmPerKm is used to store point accuracy in centimeters.
+	cmPerKm = 1e5
+)
+
+// Columns of the locodes table. Columns after locodeLngCol are optional.
+const (
+	locodeCodeCol = iota
+	locodeLocationCol
+	locodeContinentCol
+	locodeSubDivCodeCol
+	locodeSubDivNameCol
+	locodeLatCol
+	locodeLngCol
+	locodeFunctionsCol
+	locodeStatusCol
+	locodeIATACol
+	locodeNativeCol
+	locodeUpdatedCol
+	locodeRemovedCol
+	locodeAliasesCol
+	locodeSubDivTypeCol
+	locodePointSourceCol
+	locodeAccuracyCol
+	locodeTimeZoneCol
+
+	locodesFldNum = locodeLngCol + 1
+)
+
+func unpackCountriesData(r io.Reader) (map[countryCode]countryData, error) {
+	m := make(map[countryCode]countryData)
+
+	reader := csv.NewReader(r)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return m, err
+		}
+		if len(record) < countriesFldNum {
+			return m, errors.New("bad country record fields number")
+		}
+		cc, err := countryCodeFromString(record[0])
+		if err != nil {
+			return m, err
+		}
+		m[*cc] = countryData{name: record[1]}
+	}
+	return m, nil
+}
+
+// unpackLocodesData reads locodes table into strings and countries of the DB,
+// countries must be unpacked already.
+func (db *DB) unpackLocodesData(r io.Reader) error {
+	var (
+		b      strings.Builder
+		reader = csv.NewReader(r)
+		mc     = db.countries
+		types  = newStringTable()
+		zones  = newStringTable()
+	)
+	reader.ReuseRecord = true
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if len(record) < locodesFldNum {
+			return errors.New("bad locode record fields number")
+		}
+		if len(record[locodeCodeCol]) != CountryCodeLen+LocationCodeLen {
+			return errors.New("bad locode record length")
+		}
+		if len(record[locodeLocationCol]) > math.MaxUint8 ||
+			len(record[locodeSubDivCodeCol]) > math.MaxUint8 ||
+			len(record[locodeSubDivNameCol]) > math.MaxUint8 {
+			return errors.New("record string uint8 overflow")
+		}
+		if b.Len() > math.MaxInt32 {
+			return errors.New("string buffer int32 overflow")
+		}
+		var (
+			recOffset     = uint32(b.Len())
+			locationLen   = uint8(len(record[locodeLocationCol]))
+			subDivCodeLen = uint8(len(record[locodeSubDivCodeCol]))
+			subDivNameLen = uint8(len(record[locodeSubDivNameCol]))
+		)
+
+		b.WriteString(record[locodeCodeCol][CountryCodeLen:])
+		b.WriteString(record[locodeLocationCol])
+		b.WriteString(record[locodeSubDivCodeCol])
+		b.WriteString(record[locodeSubDivNameCol])
+
+		var iataLen uint8
+		if len(record) > locodeIATACol {
+			if l := len(record[locodeIATACol]); l != 0 && l != LocationCodeLen {
+				return errors.New("bad IATA code length")
+			}
+			iataLen = uint8(len(record[locodeIATACol]))
+			b.WriteString(record[locodeIATACol])
+		}
+
+		var nativeLen uint8
+		if len(record) > locodeNativeCol {
+			if len(record[locodeNativeCol]) > math.MaxUint8 {
+				return errors.New("record string uint8 overflow")
+			}
+			nativeLen = uint8(len(record[locodeNativeCol]))
+			b.WriteString(record[locodeNativeCol])
+		}
+
+		var aliasesLen uint8
+		if len(record) > locodeAliasesCol {
+			if len(record[locodeAliasesCol]) > math.MaxUint8 {
+				return errors.New("record string uint8 overflow")
+			}
+			aliasesLen = uint8(len(record[locodeAliasesCol]))
+			b.WriteString(record[locodeAliasesCol])
+		}
+
+		cont, _ := strconv.ParseUint(record[locodeContinentCol], 10, 8)
+		var continent = Continent(uint8(cont))
+
+		// Both coordinates are empty for locations without a point.
+		var lat, lng float64
+		if record[locodeLatCol] != "" || record[locodeLngCol] != "" {
+			lat, err = strconv.ParseFloat(record[locodeLatCol], 32)
+			if err != nil {
+				return err
+			}
+			lng, err = strconv.ParseFloat(record[locodeLngCol], 32)
+			if err != nil {
+				return err
+			}
+		}
+
+		var functions Functions
+		if len(record) > locodeFunctionsCol {
+			functions, err = FunctionsFromString(record[locodeFunctionsCol])
+			if err != nil {
+				return err
+			}
+		}
+		var status Status
+		if len(record) > locodeStatusCol {
+			status, err = StatusFromString(record[locodeStatusCol])
+			if err != nil {
+				return err
+			}
+		}
+
+		var updated uint16
+		if len(record) > locodeUpdatedCol && record[locodeUpdatedCol] != "" {
+			t, err := time.Parse(updatedLayout, record[locodeUpdatedCol])
+			if err != nil {
+				return err
+			}
+			updated = monthFromTime(t)
+			if updated == 0 {
+				return errors.New("updated date out of range")
+			}
+		}
+
+		var removed bool
+		if len(record) > locodeRemovedCol {
+			switch record[locodeRemovedCol] {
+			case "":
+			case removedMark:
+				removed = true
+			default:
+				return errors.New("bad removed mark")
+			}
+		}
+
+		var subDivType uint8
+		if len(record) > locodeSubDivTypeCol {
+			n := types.index(record[locodeSubDivTypeCol])
+			if n > math.MaxUint8 {
+				return errors.New("too many subdivision types")
+			}
+			subDivType = uint8(n)
+		}
+		var timeZone uint16
+		if len(record) > locodeTimeZoneCol {
+			n := zones.index(record[locodeTimeZoneCol])
+			if n > math.MaxUint16 {
+				return errors.New("too many time zones")
+			}
+			timeZone = uint16(n)
+		}
+
+		var pointSource PointSource
+		if len(record) > locodePointSourceCol {
+			pointSource, err = PointSourceFromString(record[locodePointSourceCol])
+			if err != nil {
+				return err
+			}
+		}
+		if (pointSource == PointSourceNone) != (record[locodeLatCol] == "") {
+			return errors.New("point source doesn't match coordinates")
+		}
+		var accuracy uint32
+		if len(record) > locodeAccuracyCol && record[locodeAccuracyCol] != "" {
+			km, err := strconv.ParseFloat(record[locodeAccuracyCol], 64)
+			if err != nil {
+				return err
+			}
+			if km < 0 || km*cmPerKm > math.MaxUint32 {
+				return errors.New("bad point accuracy")
+			}
+			accuracy = uint32(math.Round(km * cmPerKm))
+			if accuracy == 0 && km > 0 {
+				// Zero means unknown accuracy.
+				accuracy = 1
+			}
+		}
+
+		cc, err := countryCodeFromString(record[locodeCodeCol][:CountryCodeLen])
+		if err != nil {
+			return err
+		}
+		rec, ok := mc[*cc]
+		if !ok {
+			return errors.New("invalid country in the DB")
+		}
+		rec.locodes = append(rec.locodes, locodesCSV{
+			point:         Point{Latitude: float32(lat), Longitude: float32(lng)},
+			offset:        recOffset,
+			locationLen:   locationLen,
+			subDivCodeLen: subDivCodeLen,
+			subDivNameLen: subDivNameLen,
+			iataLen:       iataLen,
+			nativeLen:     nativeLen,
+			aliasesLen:    aliasesLen,
+			continent:     continent,
+			functions:     functions,
+			status:        status,
+			updated:       updated,
+			removed:       removed,
+			subDivType:    subDivType,
+			pointSource:   pointSource,
+			accuracy:      accuracy,
+			timeZone:      timeZone,
+		})
+		mc[*cc] = rec
+	}
+	str := b.String()
+	for k := range mc {
+		rec := mc[k]
+		rec.locodes = slices.Clip(rec.locodes)
+		// Generated tables are sorted already, but custom ones may be not.
+		cmpCodes := func(a, b locodesCSV) int {
+			return cmp.Compare(codeFromString(str, &a), codeFromString(str, &b))
+		}
+		if !slices.IsSortedFunc(rec.locodes, cmpCodes) {
+			slices.SortFunc(rec.locodes, cmpCodes)
+		}
+		mc[k] = rec
+	}
+	db.strings = str
+	db.subDivTypes = slices.Clip(types.list)
+	db.timeZones = slices.Clip(zones.list)
+	return nil
+}
+
+// stringTable interns repeated strings of locodes table, the first one is
+// always empty.
+type stringTable struct {
+	list []string
+	idx  map[string]int
+}
+
+func newStringTable() *stringTable {
+	return &stringTable{
+		list: []string{""},
+		idx:  map[string]int{"": 0},
+	}
+}
+
+// index returns the index of the string in the table adding it if needed.
+func (t *stringTable) index(s string) int {
+	n, ok := t.idx[s]
+	if !ok {
+		n = len(t.list)
+		t.list = append(t.list, s)
+		t.idx[s] = n
+	}
+	return n
+}
+
+func codeFromString(s string, c *locodesCSV) string {
+	return s[c.offset : c.offset+LocationCodeLen]
+}
+
+func (db *DB) codeFromCSV(c *locodesCSV) string {
+	return codeFromString(db.strings, c)
+}
+
+func (db *DB) locFromCSV(c *locodesCSV) string {
+	return db.strings[c.offset+LocationCodeLen : c.offset+LocationCodeLen+uint32(c.locationLen)]
+}
+
+func (db *DB) divCodeFromCSV(c *locodesCSV) string {
+	return db.strings[c.offset+LocationCodeLen+uint32(c.locationLen) : c.offset+LocationCodeLen+uint32(c.locationLen)+uint32(c.subDivCodeLen)]
+}
+
+func (db *DB) divNameFromCSV(c *locodesCSV) string {
+	return db.strings[c.offset+LocationCodeLen+uint32(c.locationLen)+uint32(c.subDivCodeLen) : c.offset+LocationCodeLen+uint32(c.locationLen)+uint32(c.subDivCodeLen)+uint32(c.subDivNameLen)]
+}
+
+// iataFromCSV returns IATA code of the location if it's different from the
+// location code.
+func (db *DB) iataFromCSV(c *locodesCSV) string {
+	off := c.offset + LocationCodeLen + uint32(c.locationLen) + uint32(c.subDivCodeLen) + uint32(c.subDivNameLen)
+	return db.strings[off : off+uint32(c.iataLen)]
+}
+
+// nativeFromCSV returns native name of the location if it's different from
+// the ASCII one.
+func (db *DB) nativeFromCSV(c *locodesCSV) string {
+	off := c.offset + LocationCodeLen + uint32(c.locationLen) + uint32(c.subDivCodeLen) + uint32(c.subDivNameLen) + uint32(c.iataLen)
+	return db.strings[off : off+uint32(c.nativeLen)]
+}
+
+// aliasesFromCSV returns alternative names of the location joined with
+// aliasSeparator.
+func (db *DB) aliasesFromCSV(c *locodesCSV) string {
+	off := c.offset + LocationCodeLen + uint32(c.locationLen) + uint32(c.subDivCodeLen) + uint32(c.subDivNameLen) + uint32(c.iataLen) + uint32(c.nativeLen)
+	return db.strings[off : off+uint32(c.aliasesLen)]
+}
@@ -0,0 +1,139 @@
+package locodedb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testCountries = `FR,France
+RU,Russia
+SE,Sweden
+`
+	testLocodes = `RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665
+RULED,Sankt-Peterburg,1,SPE,Sankt-Peterburg,59.88333,30.25
+FRPAR,Paris,1,75,Paris,48.86667,2.333333
+SESTO,Stockholm,1,AB,Stockholms län [SE-01],59.333332,18.05
+RUKGD,Kaliningrad,1,KGD,Kaliningradskaya oblast',54.716667,20.5
+`
+)
+
+func openTestDB(t *testing.T, countries, locodes string) *locodedb.DB {
+	db, err := locodedb.Open(strings.NewReader(countries), strings.NewReader(locodes))
+	require.NoError(t, err)
+	return db
+}
+
+func TestOpen(t *testing.T) {
+	db := openTestDB(t, testCountries, testLocodes)
+
+	rec, err := db.Get("RU MOW")
+	require.NoError(t, err)
+	require.Equal(t, locodedb.Record{
+		Country:        "Russia",
+		Location:       "Moskva",
+		LocationNative: "Moskva",
+		SubDivName:     "Moskva",
+		SubDivCode:     "MOW",
+		Point:          locodedb.Point{Latitude: 55.75, Longitude: 37.616665},
+		Cont:           locodedb.ContinentEurope,
+		IATA:           "MOW",
+	}, rec)
+
+	_, err = db.Get("RUSVO")
+	require.ErrorIs(t, err, locodedb.ErrNotFound)
+	_, err = db.Get("DEBER")
+	require.ErrorIs(t, err, locodedb.ErrNotFound)
+
+	var codes []string
+	for code := range db.All() {
+		codes = append(codes, code)
+	}
+	require.Equal(t, []string{"FRPAR", "RUKGD", "RULED", "RUMOW", "SESTO"}, codes)
+
+	var countries []locodedb.Country
+	for c := range db.Countries() {
+		countries = append(countries, c)
+	}
+	require.Equal(t, []locodedb.Country{
+		{Code: "FR", Name: "France", Locations: 1},
+		{Code: "RU", Name: "Russia", Locations: 3},
+		{Code: "SE", Name: "Sweden", Locations: 1},
+	}, countries)
+
+	sd, err := db.GetSubdivision("SE", "AB")
+	require.NoError(t, err)
+	require.Equal(t, locodedb.Subdivision{Code: "AB", Name: "Stockholms län [SE-01]", Locations: 1}, sd)
+
+	code, _, _, err := db.Nearest(locodedb.Point{Latitude: 59.9, Longitude: 30.3})
+	require.NoError(t, err)
+	require.Equal(t, "RULED", code)
+
+	d, err := db.DistanceBetween("RU MOW", "RU LED")
+	require.NoError(t, err)
+	require.InDelta(t, 630, d, 20)
+
+	codes = codes[:0]
+	for code := range db.Search("stockholms lan") {
+		codes = append(codes, code)
+	}
+	require.Equal(t, []string{"SESTO"}, codes)
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, tc := range []struct {
+			name, countries, locodes string
+		}{
+			{"country code", "RUS,Russia\n", testLocodes},
+			{"unknown country", "RU,Russia\n", testLocodes},
+			{"country fields", "RU\n", testLocodes},
+			{"locode", testCountries, "RUMO,Moskva,1,MOW,Moskva,55.75,37.616665\n"},
+			{"latitude", testCountries, "RUMOW,Moskva,1,MOW,Moskva,north,37.616665\n"},
+			{"fields", testCountries, "RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665\nRULED,Sankt-Peterburg\n"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := locodedb.Open(strings.NewReader(tc.countries), strings.NewReader(tc.locodes))
+				require.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestSubDivType(t *testing.T) {
+	db := openTestDB(t, testCountries, `RULED,Sankt-Peterburg,1,SPE,Sankt-Peterburg,59.88333,30.25,1234----,AI,,,,,,Autonomous city
+RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,,,,,,Autonomous city
+RUSVO,Sheremetyevo Apt/Moskva,1,MOS,Moskovskaya oblast',55.966667,37.416667,---4----,AI,,,,,,Region
+SESTO,Stockholm,1,AB,Stockholms län,59.333332,18.05,12345---,AI,,,,,,County
+FRPAR,Paris,1,,,48.86667,2.333333,1234----,AI,,,,,,
+`)
+
+	for code, typ := range map[string]string{
+		"RULED": "Autonomous city",
+		"RUMOW": "Autonomous city",
+		"RUSVO": "Region",
+		"SESTO": "County",
+		"FRPAR": "",
+	} {
+		rec, err := db.Get(code)
+		require.NoError(t, err)
+		require.Equal(t, typ, rec.SubDivType, code)
+	}
+
+	var subDivs []locodedb.Subdivision
+	for sd := range db.Subdivisions("RU") {
+		subDivs = append(subDivs, sd)
+	}
+	require.Equal(t, []locodedb.Subdivision{
+		{Code: "MOS", Name: "Moskovskaya oblast'", Type: "Region", Locations: 1},
+		{Code: "MOW", Name: "Moskva", Type: "Autonomous city", Locations: 1},
+		{Code: "SPE", Name: "Sankt-Peterburg", Type: "Autonomous city", Locations: 1},
+	}, subDivs)
+
+	// Old tables have no type column.
+	db = openTestDB(t, testCountries, testLocodes)
+	sd, err := db.GetSubdivision("RU", "MOW")
+	require.NoError(t, err)
+	require.Empty(t, sd.Type)
+}
@@ -0,0 +1,172 @@
+package locodedb
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// DiffKind is a kind of difference between two DB versions.
+type DiffKind uint8
+
+const (
+	// DiffAdded is a new LOCODE.
+	DiffAdded DiffKind = iota
+
+	// DiffRemoved is a LOCODE missing in the new DB.
+	DiffRemoved
+
+	// DiffRenamed is a change of the location name.
+	DiffRenamed
+
+	// DiffContinent is a change of the continent.
+	DiffContinent
+
+	// DiffSubdivision is a change of the subdivision code.
+	DiffSubdivision
+
+	// DiffMoved is a change of the point above the threshold or a point
+	// that became known or unknown.
+	DiffMoved
+)
+
+// diffKindNames are string representations of DiffKind values.
+var diffKindNames = [...]string{
+	DiffAdded:       "added",
+	DiffRemoved:     "removed",
+	DiffRenamed:     "renamed",
+	DiffContinent:   "continent",
+	DiffSubdivision: "subdivision",
+	DiffMoved:       "moved",
+}
+
+// String returns a string representation of the DiffKind ("added", "removed",
+// "renamed", "continent", "subdivision" or "moved").
+func (k DiffKind) String() string {
+	if int(k) >= len(diffKindNames) {
+		return "unknown"
+	}
+	return diffKindNames[k]
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (k DiffKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// DiffEntry is a single difference between two DB versions.
+type DiffEntry struct {
+	Kind DiffKind `json:"kind"`
+	// LOCODE is a LOCODE string without space separator.
+	LOCODE string `json:"locode"`
+	// Old and New are the values before and after the change: location
+	// names for DiffAdded, DiffRemoved and DiffRenamed, continent names,
+	// subdivision codes and "lat,lng" points (empty if unknown) for other
+	// kinds.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Distance is the distance of DiffMoved in kilometers, zero if any of
+	// points is not known.
+	Distance float64 `json:"distance,omitempty"`
+}
+
+// String returns a one-line human-readable description of the entry.
+func (e DiffEntry) String() string {
+	switch e.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("+ %s %s", e.LOCODE, e.New)
+	case DiffRemoved:
+		return fmt.Sprintf("- %s %s", e.LOCODE, e.Old)
+	case DiffMoved:
+		if e.Distance != 0 {
+			return fmt.Sprintf("~ %s %s %.1f km: %s -> %s", e.LOCODE, e.Kind, e.Distance, e.Old, e.New)
+		}
+	}
+	return fmt.Sprintf("~ %s %s: %q -> %q", e.LOCODE, e.Kind, e.Old, e.New)
+}
+
+// Diff returns an iterator over differences between the old and the new DB
+// ordered by LOCODE. Points of the same location are compared only if they
+// are more than minMove kilometers apart.
+func Diff(oldDB, newDB *DB, minMove float64) iter.Seq[DiffEntry] {
+	return func(yield func(DiffEntry) bool) {
+		nextOld, stopOld := iter.Pull2(oldDB.All())
+		defer stopOld()
+		nextNew, stopNew := iter.Pull2(newDB.All())
+		defer stopNew()
+
+		oldCode, oldRec, okOld := nextOld()
+		newCode, newRec, okNew := nextNew()
+		for okOld || okNew {
+			switch c := compareCodes(oldCode, okOld, newCode, okNew); {
+			case c < 0:
+				if !yield(DiffEntry{Kind: DiffRemoved, LOCODE: oldCode, Old: oldRec.Location}) {
+					return
+				}
+				oldCode, oldRec, okOld = nextOld()
+			case c > 0:
+				if !yield(DiffEntry{Kind: DiffAdded, LOCODE: newCode, New: newRec.Location}) {
+					return
+				}
+				newCode, newRec, okNew = nextNew()
+			default:
+				for _, e := range diffRecords(oldCode, oldRec, newRec, minMove) {
+					if !yield(e) {
+						return
+					}
+				}
+				oldCode, oldRec, okOld = nextOld()
+				newCode, newRec, okNew = nextNew()
+			}
+		}
+	}
+}
+
+// compareCodes compares LOCODEs of two sequences, the finished sequence is
+// greater than any LOCODE.
+func compareCodes(a string, okA bool, b string, okB bool) int {
+	switch {
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func diffRecords(code string, oldRec, newRec Record, minMove float64) []DiffEntry {
+	var res []DiffEntry
+
+	if oldRec.Location != newRec.Location {
+		res = append(res, DiffEntry{Kind: DiffRenamed, LOCODE: code, Old: oldRec.Location, New: newRec.Location})
+	}
+	if oldRec.Cont != newRec.Cont {
+		res = append(res, DiffEntry{Kind: DiffContinent, LOCODE: code, Old: oldRec.Cont.String(), New: newRec.Cont.String()})
+	}
+	if oldRec.SubDivCode != newRec.SubDivCode {
+		res = append(res, DiffEntry{Kind: DiffSubdivision, LOCODE: code, Old: oldRec.SubDivCode, New: newRec.SubDivCode})
+	}
+
+	switch {
+	case oldRec.HasPoint() && newRec.HasPoint():
+		if d := oldRec.Point.Distance(newRec.Point); d > minMove {
+			res = append(res, DiffEntry{Kind: DiffMoved, LOCODE: code, Old: pointString(oldRec), New: pointString(newRec), Distance: d})
+		}
+	case oldRec.HasPoint() != newRec.HasPoint():
+		res = append(res, DiffEntry{Kind: DiffMoved, LOCODE: code, Old: pointString(oldRec), New: pointString(newRec)})
+	}
+
+	return res
+}
+
+// pointString returns "lat,lng" string of the record point, empty if it's not
+// known.
+func pointString(r Record) string {
+	if !r.HasPoint() {
+		return ""
+	}
+	return strconv.FormatFloat(float64(r.Point.Latitude), 'f', -1, 32) + "," +
+		strconv.FormatFloat(float64(r.Point.Longitude), 'f', -1, 32)
+}
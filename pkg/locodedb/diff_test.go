@@ -0,0 +1,49 @@
+package locodedb_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	const tail = ",,,,,,,,,,"
+	oldDB := openTestDB(t, testCountries, `RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665`+tail+`
+RULED,Sankt-Peterburg,1,SPE,Sankt-Peterburg,59.88333,30.25`+tail+`
+RUKGD,Kaliningrad,1,KGD,Kaliningradskaya oblast',54.716667,20.5`+tail+`
+RUZZZ,Nowhere,1,MOS,Moskovskaya oblast',,,,,,,,,,,none,
+SESTO,Stockholm,1,AB,Stockholms län,59.333332,18.05`+tail+`
+`)
+	newDB := openTestDB(t, testCountries, `RUMOW,Moskva,1,MOW,Moskva,55.75,37.62`+tail+`
+RULED,Leningrad,1,LEN,Leningradskaya oblast',59.88333,30.25`+tail+`
+RUZZZ,Nowhere,5,MOS,Moskovskaya oblast',55.75,37.616665,,,,,,,,,unlocode,
+SESOE,Sodertalje,1,AB,Stockholms län,59.2,17.616667`+tail+`
+SESTO,Stockholm,1,AB,Stockholms län,59.333332,18.05`+tail+`
+`)
+
+	entries := slices.Collect(locodedb.Diff(oldDB, newDB, 1))
+	require.Equal(t, []locodedb.DiffEntry{
+		{Kind: locodedb.DiffRemoved, LOCODE: "RUKGD", Old: "Kaliningrad"},
+		{Kind: locodedb.DiffRenamed, LOCODE: "RULED", Old: "Sankt-Peterburg", New: "Leningrad"},
+		{Kind: locodedb.DiffSubdivision, LOCODE: "RULED", Old: "SPE", New: "LEN"},
+		{Kind: locodedb.DiffContinent, LOCODE: "RUZZZ", Old: "Europe", New: "Asia"},
+		{Kind: locodedb.DiffMoved, LOCODE: "RUZZZ", New: "55.75,37.616665"},
+		{Kind: locodedb.DiffAdded, LOCODE: "SESOE", New: "Sodertalje"},
+	}, entries)
+
+	// RUMOW moved by ~200 m.
+	entries = slices.Collect(locodedb.Diff(oldDB, newDB, 0.1))
+	require.Equal(t, locodedb.DiffMoved, entries[3].Kind)
+	require.Equal(t, "RUMOW", entries[3].LOCODE)
+	require.InDelta(t, 0.21, entries[3].Distance, 0.01)
+	require.Equal(t, "~ RUMOW moved 0.2 km: 55.75,37.616665 -> 55.75,37.62", entries[3].String())
+
+	require.Empty(t, slices.Collect(locodedb.Diff(newDB, newDB, 0)))
+
+	b, err := json.Marshal(entries[0])
+	require.NoError(t, err)
+	require.JSONEq(t, `{"kind":"removed","locode":"RUKGD","old":"Kaliningrad"}`, string(b))
+}
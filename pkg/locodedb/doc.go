@@ -2,8 +2,13 @@
 Package locodedb implements a UN LOCODE database.
 
 It contains all the data internally and provides simple [Get] API to retrieve
-records based on short LOCODE strings. The DB is stored compressed before the
-first use (~1MB) and is unpacked automatically on the first access (which takes
-~100-200ms). Unpacked it needs ~4MB of RAM.
+records based on short LOCODE strings, [All] can be used to iterate over the
+whole DB. The DB is stored compressed before the first use (~1MB) and is
+unpacked automatically on the first access (which takes ~100-200ms). Unpacked
+it needs ~4MB of RAM.
+
+Package-level functions work with the embedded data, other data sets (like a
+newer UN/LOCODE release generated by this repository tools) can be loaded with
+[Open], the resulting [DB] provides the same API.
 */
 package locodedb
@@ -0,0 +1,140 @@
+package locodedb
+
+import (
+	"fmt"
+)
+
+// FunctionsLen is the length of UN/LOCODE function classifier string.
+const FunctionsLen = 8
+
+// Functions is a set of UN/LOCODE function classifiers of the location (like
+// port or airport). Zero value means that functions are not known.
+type Functions uint8
+
+const (
+	// FunctionPort is a port, as defined in UN/ECE Recommendation 16.
+	FunctionPort Functions = 1 << iota
+
+	// FunctionRail is a rail terminal.
+	FunctionRail
+
+	// FunctionRoad is a road terminal.
+	FunctionRoad
+
+	// FunctionAirport is an airport.
+	FunctionAirport
+
+	// FunctionPostal is a postal exchange office.
+	FunctionPostal
+
+	// FunctionMultimodal is a multimodal function (ICD, etc).
+	FunctionMultimodal
+
+	// FunctionFixedTransport is a fixed transport function (like an oil
+	// platform).
+	FunctionFixedTransport
+
+	// FunctionBorderCrossing is a border crossing.
+	FunctionBorderCrossing
+)
+
+// functionSymbols are the symbols of function classifiers in the order of
+// bits and positions in the UN/LOCODE string.
+const functionSymbols = "1234567B"
+
+// FunctionsFromString parses UN/LOCODE function classifier string like
+// "1-3-----". Every position of the string contains either the symbol of the
+// function or '-', "0" in the first position means that functions are not
+// known. Empty string is treated as unknown functions too.
+func FunctionsFromString(s string) (Functions, error) {
+	var f Functions
+
+	if len(s) == 0 {
+		return f, nil
+	}
+	if len(s) != FunctionsLen {
+		return f, fmt.Errorf("%w: incorrect function classifier length: expect: %d, got: %d",
+			ErrInvalidString, FunctionsLen, len(s))
+	}
+
+	for i := range len(s) {
+		switch s[i] {
+		case '-':
+		case '0':
+			if i != 0 {
+				return 0, fmt.Errorf("%w: unexpected function symbol %q at %d", ErrInvalidString, s[i], i)
+			}
+		case functionSymbols[i]:
+			f |= 1 << i
+		default:
+			return 0, fmt.Errorf("%w: unexpected function symbol %q at %d", ErrInvalidString, s[i], i)
+		}
+	}
+
+	return f, nil
+}
+
+// String returns UN/LOCODE function classifier string like "1-3-----",
+// unknown functions are represented as "0-------".
+func (f Functions) String() string {
+	var b [FunctionsLen]byte
+
+	for i := range b {
+		if f&(1<<i) != 0 {
+			b[i] = functionSymbols[i]
+		} else {
+			b[i] = '-'
+		}
+	}
+	if f == 0 {
+		b[0] = '0'
+	}
+
+	return string(b[:])
+}
+
+// Has checks whether all the given functions are set.
+func (f Functions) Has(other Functions) bool {
+	return f&other == other
+}
+
+// HasPort checks whether the location is a port.
+func (f Functions) HasPort() bool {
+	return f.Has(FunctionPort)
+}
+
+// HasRail checks whether the location is a rail terminal.
+func (f Functions) HasRail() bool {
+	return f.Has(FunctionRail)
+}
+
+// HasRoad checks whether the location is a road terminal.
+func (f Functions) HasRoad() bool {
+	return f.Has(FunctionRoad)
+}
+
+// HasAirport checks whether the location is an airport.
+func (f Functions) HasAirport() bool {
+	return f.Has(FunctionAirport)
+}
+
+// HasPostal checks whether the location is a postal exchange office.
+func (f Functions) HasPostal() bool {
+	return f.Has(FunctionPostal)
+}
+
+// HasMultimodal checks whether the location has multimodal functions.
+func (f Functions) HasMultimodal() bool {
+	return f.Has(FunctionMultimodal)
+}
+
+// HasFixedTransport checks whether the location has fixed transport
+// functions.
+func (f Functions) HasFixedTransport() bool {
+	return f.Has(FunctionFixedTransport)
+}
+
+// HasBorderCrossing checks whether the location is a border crossing.
+func (f Functions) HasBorderCrossing() bool {
+	return f.Has(FunctionBorderCrossing)
+}
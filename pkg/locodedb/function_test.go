@@ -0,0 +1,93 @@
+package locodedb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionsFromString(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		exp locodedb.Functions
+	}{
+		{"", 0},
+		{"0-------", 0},
+		{"--------", 0},
+		{"1-------", locodedb.FunctionPort},
+		{"--3-----", locodedb.FunctionRoad},
+		{"1234----", locodedb.FunctionPort | locodedb.FunctionRail | locodedb.FunctionRoad | locodedb.FunctionAirport},
+		{"-----67B", locodedb.FunctionMultimodal | locodedb.FunctionFixedTransport | locodedb.FunctionBorderCrossing},
+		{"1234567B", 0xff},
+	} {
+		f, err := locodedb.FunctionsFromString(tc.s)
+		require.NoError(t, err, tc.s)
+		require.Equal(t, tc.exp, f, tc.s)
+		if tc.s != "" && tc.s != "--------" {
+			require.Equal(t, tc.s, f.String())
+		}
+	}
+
+	for _, s := range []string{"1", "1-3------", "3-------", "--0-----", "A-------", "1234567C"} {
+		_, err := locodedb.FunctionsFromString(s)
+		require.ErrorIs(t, err, locodedb.ErrInvalidString, s)
+	}
+
+	f := locodedb.FunctionPort | locodedb.FunctionAirport
+	require.True(t, f.HasPort())
+	require.True(t, f.HasAirport())
+	require.False(t, f.HasRail())
+	require.False(t, f.HasRoad())
+	require.False(t, f.HasPostal())
+	require.False(t, f.HasMultimodal())
+	require.False(t, f.HasFixedTransport())
+	require.False(t, f.HasBorderCrossing())
+	require.True(t, f.Has(locodedb.FunctionPort|locodedb.FunctionAirport))
+	require.False(t, f.Has(locodedb.FunctionPort|locodedb.FunctionRail))
+}
+
+func TestFunctionsFilter(t *testing.T) {
+	db := openTestDB(t, testCountries, `FRPAR,Paris,1,75,Paris,48.86667,2.333333,1234----
+RULED,Sankt-Peterburg,1,SPE,Sankt-Peterburg,59.88333,30.25,1234----
+RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,-234----
+RUSVO,Sheremetyevo Apt/Moskva,1,MOS,Moskovskaya oblast',55.9726,37.4146,---4----
+RUZZZ,Zelenograd,1,MOS,Moskovskaya oblast',55.98,37.18,0-------
+`)
+
+	rec, err := db.Get("RUSVO")
+	require.NoError(t, err)
+	require.True(t, rec.Functions.HasAirport())
+	require.False(t, rec.Functions.HasPort())
+
+	codes := func(seq func(func(string, locodedb.Record) bool)) string {
+		var res []string
+		for code := range seq {
+			res = append(res, code)
+		}
+		return strings.Join(res, ",")
+	}
+
+	require.Equal(t, "RUMOW,RUSVO", codes(db.Search("mosk", locodedb.WithFunctions(locodedb.FunctionAirport))))
+	require.Equal(t, "", codes(db.Search("mosk", locodedb.WithFunctions(locodedb.FunctionPort))))
+	require.Equal(t, "FRPAR,RULED", codes(db.Search("", locodedb.WithFunctions(locodedb.FunctionPort))))
+	require.Equal(t, "RULED", codes(db.Search("", locodedb.WithFunctions(locodedb.FunctionPort), locodedb.WithCountry("RU"))))
+	require.Equal(t, "RUSVO", codes(db.Complete("sh", 10, locodedb.WithFunctions(locodedb.FunctionAirport))))
+
+	zelenograd := locodedb.Point{Latitude: 55.98, Longitude: 37.18}
+	code, _, _, err := db.Nearest(zelenograd)
+	require.NoError(t, err)
+	require.Equal(t, "RUZZZ", code)
+	code, _, _, err = db.Nearest(zelenograd, locodedb.WithFunctions(locodedb.FunctionAirport))
+	require.NoError(t, err)
+	require.Equal(t, "RUSVO", code)
+	code, _, _, err = db.Nearest(zelenograd, locodedb.WithFunctions(locodedb.FunctionPort))
+	require.NoError(t, err)
+	require.Equal(t, "RULED", code)
+	_, _, _, err = db.Nearest(zelenograd, locodedb.WithCountry("SE"))
+	require.ErrorIs(t, err, locodedb.ErrNotFound)
+
+	require.Equal(t, "RUMOW", codes(db.WithinRadius(zelenograd, 50, locodedb.WithFunctions(locodedb.FunctionRail))))
+	require.Equal(t, "RULED", codes(db.WithinBBox(50, 20, 70, 40, locodedb.WithFunctions(locodedb.FunctionPort))))
+}
@@ -0,0 +1,84 @@
+package locodedb
+
+import (
+	"bytes"
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// iataEntry is an element of IATA index pointing to the location with the
+// given (effective) IATA code.
+type iataEntry struct {
+	code [LocationCodeLen]uint8
+	cc   countryCode
+	idx  uint32
+}
+
+// GetByIATA returns an iterator over records of the default DB with the given
+// IATA code. If the DB can't be unpacked, the sequence is empty. See
+// [DB.GetByIATA] for details.
+func GetByIATA(code string) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.GetByIATA(code)(yield)
+	}
+}
+
+// GetByIATA returns an iterator over records with the given 3-letter IATA
+// code (like "SVO"). IATA code of the location is the same as its location
+// code unless UN/LOCODE defines a different one, so the same code can match
+// several locations in different countries. Records are ordered by LOCODE,
+// keys are LOCODE strings without space separator. The sequence is empty if
+// nothing is found or the code is invalid.
+//
+// IATA index is built on the first call of GetByIATA.
+func (db *DB) GetByIATA(code string) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if len(code) != LocationCodeLen {
+			return
+		}
+
+		db.iataOnce.Do(func() {
+			db.iata = db.newIATAIndex()
+		})
+
+		i, _ := slices.BinarySearchFunc(db.iata, code, func(e iataEntry, s string) int {
+			return cmp.Compare(string(e.code[:]), s)
+		})
+		for ; i < len(db.iata) && string(db.iata[i].code[:]) == code; i++ {
+			if !yield(db.record(db.iata[i].cc, db.iata[i].idx)) {
+				return
+			}
+		}
+	}
+}
+
+func (db *DB) newIATAIndex() []iataEntry {
+	var num int
+	for _, cd := range db.countries {
+		num += len(cd.locodes)
+	}
+
+	idx := make([]iataEntry, 0, num)
+	for _, cc := range db.codes {
+		cd := db.countries[cc]
+		for i := range cd.locodes {
+			e := iataEntry{cc: cc, idx: uint32(i)}
+			iata := db.iataFromCSV(&cd.locodes[i])
+			if iata == "" {
+				iata = db.codeFromCSV(&cd.locodes[i])
+			}
+			copy(e.code[:], iata)
+			idx = append(idx, e)
+		}
+	}
+
+	// Stable sort keeps LOCODE order of the same IATA codes.
+	slices.SortStableFunc(idx, func(a, b iataEntry) int {
+		return bytes.Compare(a.code[:], b.code[:])
+	})
+	return idx
+}
@@ -0,0 +1,54 @@
+package locodedb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetByIATA(t *testing.T) {
+	db := openTestDB(t, "CH,Switzerland\nFR,France\nRU,Russia\n", `CHBSL,Basel,1,BS,Basel-Stadt,47.55,7.583333,1234----,AI,
+FRMLH,Mulhouse,1,68,Haut-Rhin,47.75,7.333333,1234----,AI,BSL
+RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,
+RUSVO,Sheremetyevo,1,MOS,Moskovskaya oblast',55.966667,37.416667,---4----,AI,SVO
+`)
+
+	get := func(code string) []string {
+		var res []string
+		for k, rec := range db.GetByIATA(code) {
+			require.Equal(t, code, rec.IATA)
+			res = append(res, k)
+		}
+		return res
+	}
+
+	require.Equal(t, []string{"CHBSL", "FRMLH"}, get("BSL"))
+	require.Equal(t, []string{"RUSVO"}, get("SVO"))
+	require.Equal(t, []string{"RUMOW"}, get("MOW"))
+	require.Empty(t, get("MLH"))
+	require.Empty(t, get("LED"))
+	require.Empty(t, get("SV"))
+
+	rec, err := db.Get("FRMLH")
+	require.NoError(t, err)
+	require.Equal(t, "BSL", rec.IATA)
+
+	// Old tables have no IATA column.
+	db = openTestDB(t, testCountries, testLocodes)
+	rec, err = db.Get("RUMOW")
+	require.NoError(t, err)
+	require.Equal(t, "MOW", rec.IATA)
+	require.Equal(t, []string{"RUMOW"}, get("MOW"))
+
+	_, err = locodedb.Open(strings.NewReader(testCountries), strings.NewReader("RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,MO\n"))
+	require.Error(t, err)
+
+	// Embedded data has no IATA column, so location code is used.
+	var codes []string
+	for k := range locodedb.GetByIATA("LED") {
+		codes = append(codes, k)
+	}
+	require.Contains(t, codes, "RULED")
+}
@@ -0,0 +1,105 @@
+package locodedb
+
+// LOCODE is a UN/LOCODE consisting of ISO 3166 alpha-2 country code and
+// location code. Zero value is an empty (not set) LOCODE.
+//
+// LOCODE implements [encoding.TextMarshaler] and [encoding.TextUnmarshaler],
+// so it can be used in JSON, YAML, flags and other text-based formats.
+type LOCODE struct {
+	country  countryCode
+	location [LocationCodeLen]uint8
+}
+
+// Parse parses a LOCODE string. The string must be 5 or 6 letters long. The
+// first 2 letters are country code followed by an optional space separator
+// and 3 letters or digits of the location code, like "RU MOW" or "RUMOW".
+//
+// Returns ErrInvalidString if the string doesn't match the format.
+func Parse(s string) (LOCODE, error) {
+	var l LOCODE
+
+	s, err := normalizeLOCODE(s)
+	if err != nil {
+		return l, err
+	}
+
+	copy(l.country[:], s[:CountryCodeLen])
+	copy(l.location[:], s[CountryCodeLen:])
+
+	return l, nil
+}
+
+// normalizeLOCODE checks the LOCODE string and returns it without space
+// separator.
+func normalizeLOCODE(s string) (string, error) {
+	if len(s) == CountryCodeLen+LocationCodeLen+1 && s[CountryCodeLen] == ' ' {
+		s = s[:CountryCodeLen] + s[CountryCodeLen+1:]
+	}
+	if len(s) != CountryCodeLen+LocationCodeLen {
+		return "", ErrInvalidString
+	}
+
+	for i := range s[:CountryCodeLen] {
+		if !isUpperAlpha(s[i]) {
+			return "", ErrInvalidString
+		}
+	}
+	for i := range s[CountryCodeLen:] {
+		if !isUpperAlpha(s[CountryCodeLen+i]) && !isDigit(s[CountryCodeLen+i]) {
+			return "", ErrInvalidString
+		}
+	}
+
+	return s, nil
+}
+
+// Country returns ISO 3166 alpha-2 country code of the LOCODE.
+func (l LOCODE) Country() string {
+	if l.IsZero() {
+		return ""
+	}
+	return string(l.country[:])
+}
+
+// Location returns location code of the LOCODE.
+func (l LOCODE) Location() string {
+	if l.IsZero() {
+		return ""
+	}
+	return string(l.location[:])
+}
+
+// IsZero checks whether the LOCODE is empty.
+func (l LOCODE) IsZero() bool {
+	return l == LOCODE{}
+}
+
+// String returns the LOCODE in canonical form with space separator, like
+// "RU MOW". Empty string is returned for zero LOCODE.
+func (l LOCODE) String() string {
+	if l.IsZero() {
+		return ""
+	}
+	return string(l.country[:]) + " " + string(l.location[:])
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (l LOCODE) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler]. Empty text results in
+// zero LOCODE, otherwise the text is checked with [Parse].
+func (l *LOCODE) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*l = LOCODE{}
+		return nil
+	}
+
+	res, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*l = res
+	return nil
+}
@@ -0,0 +1,72 @@
+package locodedb_test
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, s := range []string{"RU MOW", "RUMOW"} {
+		l, err := locodedb.Parse(s)
+		require.NoError(t, err)
+		require.Equal(t, "RU", l.Country())
+		require.Equal(t, "MOW", l.Location())
+		require.Equal(t, "RU MOW", l.String())
+		require.False(t, l.IsZero())
+	}
+
+	l, err := locodedb.Parse("AT4ST")
+	require.NoError(t, err)
+	require.Equal(t, "AT 4ST", l.String())
+
+	for _, s := range []string{"", "RU", "RU  MOW", "RU-MOW", "ru mow", "R1MOW", "RUMOW1", "RU mow"} {
+		_, err := locodedb.Parse(s)
+		require.ErrorIs(t, err, locodedb.ErrInvalidString, s)
+	}
+
+	var zero locodedb.LOCODE
+	require.True(t, zero.IsZero())
+	require.Empty(t, zero.String())
+	require.Empty(t, zero.Country())
+	require.Empty(t, zero.Location())
+
+	rec, err := locodedb.GetLOCODE(l)
+	require.NoError(t, err)
+	exp, err := locodedb.Get("AT4ST")
+	require.NoError(t, err)
+	require.Equal(t, exp, rec)
+
+	_, err = locodedb.GetLOCODE(zero)
+	require.ErrorIs(t, err, locodedb.ErrNotFound)
+}
+
+func TestLOCODEText(t *testing.T) {
+	type config struct {
+		Location locodedb.LOCODE `json:"location"`
+		Empty    locodedb.LOCODE `json:"empty"`
+	}
+
+	var c config
+	require.NoError(t, json.Unmarshal([]byte(`{"location":"RUMOW","empty":""}`), &c))
+	require.Equal(t, "RU MOW", c.Location.String())
+	require.True(t, c.Empty.IsZero())
+
+	data, err := json.Marshal(c)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"location":"RU MOW","empty":""}`, string(data))
+
+	require.Error(t, json.Unmarshal([]byte(`{"location":"Moscow"}`), &c))
+
+	var (
+		l  locodedb.LOCODE
+		fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	)
+	fs.TextVar(&l, "locode", locodedb.LOCODE{}, "LOCODE")
+	require.NoError(t, fs.Parse([]string{"-locode", "SE STO"}))
+	require.Equal(t, "SE", l.Country())
+	require.Equal(t, "STO", l.Location())
+}
@@ -0,0 +1,91 @@
+package locodedb
+
+// SearchOption sets an optional filter of search and spatial queries.
+type SearchOption func(*searchOptions)
+
+type searchOptions struct {
+	country    countryCode
+	hasCountry bool
+	// badCountry is set if the country code is invalid, nothing can match.
+	badCountry bool
+
+	continent    Continent
+	hasContinent bool
+
+	functions Functions
+}
+
+// WithCountry returns an option to limit query results to the country with
+// the given ISO 3166 alpha-2 code.
+func WithCountry(code string) SearchOption {
+	return func(o *searchOptions) {
+		cc, err := countryCodeFromString(code)
+		if err != nil {
+			o.badCountry = true
+			return
+		}
+		o.country = *cc
+		o.hasCountry = true
+	}
+}
+
+// WithContinent returns an option to limit query results to the continent.
+func WithContinent(c Continent) SearchOption {
+	return func(o *searchOptions) {
+		o.continent = c
+		o.hasContinent = true
+	}
+}
+
+// WithFunctions returns an option to limit query results to locations having
+// all the given functions. Locations with unknown functions never match.
+func WithFunctions(f Functions) SearchOption {
+	return func(o *searchOptions) {
+		o.functions |= f
+	}
+}
+
+func newSearchOptions(opts []SearchOption) *searchOptions {
+	o := new(searchOptions)
+	for i := range opts {
+		opts[i](o)
+	}
+	return o
+}
+
+// isEmpty checks whether there are no filters.
+func (o *searchOptions) isEmpty() bool {
+	return *o == searchOptions{}
+}
+
+// match checks whether the location satisfies all filters.
+func (o *searchOptions) match(cc countryCode, c *locodesCSV) bool {
+	return !o.badCountry &&
+		(!o.hasCountry || cc == o.country) &&
+		(!o.hasContinent || c.continent == o.continent) &&
+		c.functions.Has(o.functions)
+}
+
+// matchEntry checks whether the location referenced by the index entry
+// satisfies all filters.
+func (o *searchOptions) matchEntry(db *DB, cc countryCode, idx uint32) bool {
+	if o.isEmpty() {
+		return true
+	}
+	return o.match(cc, &db.countries[cc].locodes[idx])
+}
+
+// countries returns country codes to search in ordered by code.
+func (o *searchOptions) countries(db *DB) []countryCode {
+	switch {
+	case o.badCountry:
+		return nil
+	case !o.hasCountry:
+		return db.codes
+	}
+
+	if _, ok := db.countries[o.country]; !ok {
+		return nil
+	}
+	return []countryCode{o.country}
+}
@@ -1,7 +1,124 @@
 package locodedb
 
+import (
+	"fmt"
+	"math"
+)
+
+// earthRadius is the mean radius of the Earth in kilometers.
+const earthRadius = 6371.0088
+
 // Point represents a 2D geographic point.
 type Point struct {
 	Latitude  float32
 	Longitude float32
 }
+
+// isValid checks that the point has correct latitude and longitude values.
+func (p Point) isValid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// Distance returns the great-circle distance between two points in kilometers
+// calculated with the haversine formula.
+func (p Point) Distance(other Point) float64 {
+	var (
+		lat1 = degToRad(float64(p.Latitude))
+		lat2 = degToRad(float64(other.Latitude))
+		dLat = lat2 - lat1
+		dLng = degToRad(float64(other.Longitude) - float64(p.Longitude))
+		h    = math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	)
+	return 2 * earthRadius * math.Asin(math.Sqrt(min(h, 1)))
+}
+
+// Bearing returns the initial bearing (forward azimuth) of the great-circle
+// path from p to the other point in degrees clockwise from north, [0, 360).
+func (p Point) Bearing(other Point) float64 {
+	var (
+		lat1 = degToRad(float64(p.Latitude))
+		lat2 = degToRad(float64(other.Latitude))
+		dLng = degToRad(float64(other.Longitude) - float64(p.Longitude))
+		y    = math.Sin(dLng) * math.Cos(lat2)
+		x    = math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	)
+	return math.Mod(radToDeg(math.Atan2(y, x))+360, 360)
+}
+
+// Midpoint returns the point halfway along the great-circle path between p
+// and the other point.
+func (p Point) Midpoint(other Point) Point {
+	var (
+		lat1 = degToRad(float64(p.Latitude))
+		lng1 = degToRad(float64(p.Longitude))
+		lat2 = degToRad(float64(other.Latitude))
+		dLng = degToRad(float64(other.Longitude) - float64(p.Longitude))
+		bx   = math.Cos(lat2) * math.Cos(dLng)
+		by   = math.Cos(lat2) * math.Sin(dLng)
+		lat  = math.Atan2(math.Sin(lat1)+math.Sin(lat2), math.Hypot(math.Cos(lat1)+bx, by))
+		lng  = lng1 + math.Atan2(by, math.Cos(lat1)+bx)
+	)
+	return pointFromRadians(lat, lng)
+}
+
+// Destination returns the point reached by travelling the given distance (in
+// kilometers) from p along the great-circle path with the initial bearing (in
+// degrees clockwise from north).
+func (p Point) Destination(bearing, distance float64) Point {
+	var (
+		lat1 = degToRad(float64(p.Latitude))
+		lng1 = degToRad(float64(p.Longitude))
+		brng = degToRad(bearing)
+		d    = distance / earthRadius
+		lat  = math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(brng))
+		lng  = lng1 + math.Atan2(math.Sin(brng)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat))
+	)
+	return pointFromRadians(lat, lng)
+}
+
+// DistanceBetween returns the great-circle distance between two locations of
+// the default DB. See [DB.DistanceBetween] for details.
+func DistanceBetween(locodeA, locodeB string) (float64, error) {
+	if err := initLocodeData(); err != nil {
+		return 0, err
+	}
+	return defaultDB.DistanceBetween(locodeA, locodeB)
+}
+
+// DistanceBetween returns the great-circle distance between two locations in
+// kilometers. LOCODE strings are the same as for [DB.Get].
+// ErrNoPoint is returned if coordinates of any location are not known.
+func (db *DB) DistanceBetween(locodeA, locodeB string) (float64, error) {
+	a, err := db.Get(locodeA)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", locodeA, err)
+	}
+	b, err := db.Get(locodeB)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", locodeB, err)
+	}
+	if !a.HasPoint() {
+		return 0, fmt.Errorf("%s: %w", locodeA, ErrNoPoint)
+	}
+	if !b.HasPoint() {
+		return 0, fmt.Errorf("%s: %w", locodeB, ErrNoPoint)
+	}
+	return a.Point.Distance(b.Point), nil
+}
+
+// pointFromRadians returns a point with the longitude normalized to
+// [-180, 180] range.
+func pointFromRadians(lat, lng float64) Point {
+	return Point{
+		Latitude:  float32(radToDeg(lat)),
+		Longitude: float32(normalizeLongitude(radToDeg(lng))),
+	}
+}
+
+func degToRad(d float64) float64 {
+	return d * math.Pi / 180
+}
+
+func radToDeg(r float64) float64 {
+	return r * 180 / math.Pi
+}
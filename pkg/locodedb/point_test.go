@@ -0,0 +1,77 @@
+package locodedb_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoint(t *testing.T) {
+	var (
+		zero     = locodedb.Point{}
+		quarter  = math.Pi * 6371.0088 / 2
+		east     = locodedb.Point{Longitude: 90}
+		north    = locodedb.Point{Latitude: 10}
+		stockhlm = locodedb.Point{Latitude: 59.3293, Longitude: 18.0686}
+		moscow   = locodedb.Point{Latitude: 55.7558, Longitude: 37.6173}
+	)
+
+	t.Run("distance", func(t *testing.T) {
+		require.Zero(t, moscow.Distance(moscow))
+		require.InDelta(t, quarter, zero.Distance(east), 1e-3)
+		require.InDelta(t, 1228, stockhlm.Distance(moscow), 1)
+		require.Equal(t, stockhlm.Distance(moscow), moscow.Distance(stockhlm))
+	})
+
+	t.Run("bearing", func(t *testing.T) {
+		require.InDelta(t, 90, zero.Bearing(east), 1e-9)
+		require.InDelta(t, 0, zero.Bearing(north), 1e-9)
+		require.InDelta(t, 180, north.Bearing(zero), 1e-9)
+		require.InDelta(t, 270, east.Bearing(zero), 1e-9)
+	})
+
+	t.Run("midpoint", func(t *testing.T) {
+		mid := zero.Midpoint(east)
+		require.InDelta(t, 0, mid.Latitude, 1e-5)
+		require.InDelta(t, 45, mid.Longitude, 1e-5)
+
+		mid = locodedb.Point{Longitude: 179}.Midpoint(locodedb.Point{Longitude: -179})
+		require.InDelta(t, 0, mid.Latitude, 1e-5)
+		require.InDelta(t, 180, math.Abs(float64(mid.Longitude)), 1e-4)
+
+		mid = stockhlm.Midpoint(moscow)
+		require.InDelta(t, stockhlm.Distance(mid), mid.Distance(moscow), 1e-2)
+	})
+
+	t.Run("destination", func(t *testing.T) {
+		dst := zero.Destination(90, quarter)
+		require.InDelta(t, 0, dst.Latitude, 1e-5)
+		require.InDelta(t, 90, dst.Longitude, 1e-5)
+
+		dst = locodedb.Point{Longitude: 179}.Destination(90, quarter/45)
+		require.InDelta(t, -179, dst.Longitude, 1e-4)
+
+		dst = stockhlm.Destination(stockhlm.Bearing(moscow), stockhlm.Distance(moscow))
+		require.InDelta(t, moscow.Latitude, dst.Latitude, 1e-4)
+		require.InDelta(t, moscow.Longitude, dst.Longitude, 1e-4)
+	})
+}
+
+func TestDistanceBetween(t *testing.T) {
+	d, err := locodedb.DistanceBetween("RU MOW", "RULED")
+	require.NoError(t, err)
+	require.InDelta(t, 630, d, 20)
+
+	d, err = locodedb.DistanceBetween("RUMOW", "RU MOW")
+	require.NoError(t, err)
+	require.Zero(t, d)
+
+	_, err = locodedb.DistanceBetween("RU MOW", "AAAAA")
+	require.ErrorIs(t, err, locodedb.ErrNotFound)
+	_, err = locodedb.DistanceBetween("WRONG", "RU MOW")
+	require.ErrorIs(t, err, locodedb.ErrNotFound)
+	_, err = locodedb.DistanceBetween("RU MOW", "wrong locode")
+	require.ErrorIs(t, err, locodedb.ErrInvalidString)
+}
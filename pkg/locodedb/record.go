@@ -1,12 +1,79 @@
 package locodedb
 
+import (
+	"errors"
+	"time"
+)
+
 // Record represents a record in the location database (resulting CSV files). It contains all the
 // information about the location. It is used to fill the database. Country, Location are full names, codes are in Key.
 type Record struct {
-	Country    string
-	Location   string
+	Country string
+	// Location is the location name without diacritic signs (ASCII in most
+	// cases), like "Zurich".
+	Location string
+	// LocationNative is the location name with diacritic signs, like
+	// "Zürich". It's the same as Location if there is no difference or the
+	// native name is not known.
+	LocationNative string
+	// SubDivName is the subdivision name, it can be empty if only SubDivCode
+	// is known.
 	SubDivName string
 	SubDivCode string
+	// SubDivType is the type of the subdivision (like "Region" or "State"),
+	// empty if unknown.
+	SubDivType string
 	Point      Point
-	Cont       Continent
+	// PointSource shows where Point comes from. Point is zero and must not
+	// be used if it's PointSourceNone, see HasPoint.
+	PointSource PointSource
+	// Accuracy is the accuracy radius of Point in kilometers depending on
+	// the source format, zero if unknown.
+	Accuracy float64
+	// Cont is ContinentUnknown for locations without a point unless it's
+	// set by the generator overrides.
+	Cont      Continent
+	Functions Functions
+	Status    Status
+	// IATA is the IATA code of the location, it's the same as the location
+	// code unless UN/LOCODE defines a different one.
+	IATA string
+	// Updated is the month of the last change of the UN/LOCODE entry (the
+	// first day of it in UTC). Zero value means that the date is not known.
+	Updated time.Time
+	// Aliases are alternative names of the location (reference entries of
+	// UN/LOCODE), like "Moscow" for "Moskva".
+	Aliases []string
+	// Removed is set if the entry is marked for removal by the change
+	// indicator of UN/LOCODE, see also IsScheduledForRemoval.
+	Removed bool
+	// TimeZone is IANA time zone name of the location (like
+	// "Europe/Moscow"), empty if unknown.
+	TimeZone string
+}
+
+// HasPoint checks whether coordinates of the location are known.
+func (r Record) HasPoint() bool {
+	return r.PointSource != PointSourceNone
+}
+
+// ErrUnknownTimeZone is returned when the time zone of the location is not
+// known.
+var ErrUnknownTimeZone = errors.New("unknown time zone")
+
+// TimeLocation returns [time.Location] of the record's TimeZone. It uses
+// [time.LoadLocation], so time zone database must be available (import
+// time/tzdata to embed it into the program). ErrUnknownTimeZone is returned
+// if the time zone is not known.
+func (r Record) TimeLocation() (*time.Location, error) {
+	if r.TimeZone == "" {
+		return nil, ErrUnknownTimeZone
+	}
+	return time.LoadLocation(r.TimeZone)
+}
+
+// IsScheduledForRemoval checks whether the entry will be removed from the next
+// issue of UN/LOCODE, either by the change indicator or by the status.
+func (r Record) IsScheduledForRemoval() bool {
+	return r.Removed || r.Status.IsMarkedForRemoval()
 }
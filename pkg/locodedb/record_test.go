@@ -0,0 +1,92 @@
+package locodedb_test
+
+import (
+	"strings"
+	"testing"
+	_ "time/tzdata"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordChanges(t *testing.T) {
+	db := openTestDB(t, testCountries, `RULED,Sankt-Peterburg,1,SPE,Sankt-Peterburg,59.88333,30.25,1234----,AI,,,2003-07,,Leningrad
+RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,,,2009-01,,Moscow|Moskau
+RUOLD,Old place,1,MOS,Moskovskaya oblast',55.75,37.616665,--3-----,AI,,,2023-01,X,
+RUXXX,Other place,1,MOS,Moskovskaya oblast',55.75,37.616665,--3-----,XX,,,2023-01,,
+`)
+
+	rec, err := db.Get("RUMOW")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Moscow", "Moskau"}, rec.Aliases)
+	require.False(t, rec.IsScheduledForRemoval())
+
+	rec, err = db.Get("RUOLD")
+	require.NoError(t, err)
+	require.Nil(t, rec.Aliases)
+	require.True(t, rec.Removed)
+	require.True(t, rec.IsScheduledForRemoval())
+
+	rec, err = db.Get("RUXXX")
+	require.NoError(t, err)
+	require.False(t, rec.Removed)
+	require.True(t, rec.IsScheduledForRemoval())
+
+	var codes []string
+	for code := range db.Search("leningrad") {
+		codes = append(codes, code)
+	}
+	require.Equal(t, []string{"RULED"}, codes)
+
+	codes = codes[:0]
+	for code := range db.Search("mosc") {
+		codes = append(codes, code)
+	}
+	require.Equal(t, []string{"RUMOW"}, codes)
+
+	// Aliases are matched separately.
+	codes = codes[:0]
+	for code := range db.Search("w|m") {
+		codes = append(codes, code)
+	}
+	require.Empty(t, codes)
+
+	codes = codes[:0]
+	for code := range db.Complete("mosk", 10) {
+		codes = append(codes, code)
+	}
+	require.Equal(t, []string{"RUMOW"}, codes)
+
+	codes = codes[:0]
+	for code := range db.Complete("lenin", 10, locodedb.WithCountry("RU")) {
+		codes = append(codes, code)
+	}
+	require.Equal(t, []string{"RULED"}, codes)
+
+	_, err = locodedb.Open(strings.NewReader(testCountries), strings.NewReader("RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,,,2009-01,Y,\n"))
+	require.Error(t, err)
+}
+
+func TestTimeZone(t *testing.T) {
+	db := openTestDB(t, testCountries, `RUKGD,Kaliningrad,1,KGD,Kaliningradskaya oblast',54.716667,20.5,1234----,AI,,,,,,,,,Europe/Kaliningrad
+RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,,,,,,,,,Europe/Moscow
+SESTO,Stockholm,1,AB,Stockholms län,59.333332,18.05,12345---,AI,,,,,,,,,
+`)
+
+	rec, err := db.Get("RUMOW")
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Moscow", rec.TimeZone)
+	loc, err := rec.TimeLocation()
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Moscow", loc.String())
+
+	rec, err = db.Get("RUKGD")
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Kaliningrad", rec.TimeZone)
+
+	rec, err = db.Get("SESTO")
+	require.NoError(t, err)
+	require.Empty(t, rec.TimeZone)
+	_, err = rec.TimeLocation()
+	require.ErrorIs(t, err, locodedb.ErrUnknownTimeZone)
+}
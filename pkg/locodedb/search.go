@@ -0,0 +1,105 @@
+package locodedb
+
+import (
+	"iter"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Search returns an iterator over records of the default DB matching the
+// query. If the DB can't be unpacked, the sequence is empty. See [DB.Search]
+// for details.
+func Search(query string, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.Search(query, opts...)(yield)
+	}
+}
+
+// Search returns an iterator over all records with location (either ASCII,
+// native or alias) or subdivision name containing the query. Matching ignores case and
+// diacritics, so "sao paulo" matches "São Paulo". Records are ordered by
+// LOCODE, keys are LOCODE strings without space separator.
+func (db *DB) Search(query string, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		var (
+			o = newSearchOptions(opts)
+			f = newFolder()
+			q = f.fold(query)
+		)
+		for _, cc := range o.countries(db) {
+			cd := db.countries[cc]
+			for i := range cd.locodes {
+				c := &cd.locodes[i]
+				if !o.match(cc, c) {
+					continue
+				}
+				if !strings.Contains(f.fold(db.locFromCSV(c)), q) &&
+					!strings.Contains(f.fold(db.nativeFromCSV(c)), q) &&
+					!db.aliasesContain(f, c, q) &&
+					!strings.Contains(f.fold(db.divNameFromCSV(c)), q) {
+					continue
+				}
+				if !yield(string(cc[:])+db.codeFromCSV(c), db.recordFromCSV(&cd, c)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// aliasesContain checks whether any alias of the location contains the folded
+// query.
+func (db *DB) aliasesContain(f *folder, c *locodesCSV, q string) bool {
+	aliases := db.aliasesFromCSV(c)
+	if aliases == "" {
+		return false
+	}
+	for a := range strings.SplitSeq(aliases, aliasSeparator) {
+		if strings.Contains(f.fold(a), q) {
+			return true
+		}
+	}
+	return false
+}
+
+// folder converts strings to a case- and diacritic-insensitive form. It is
+// not safe for concurrent use.
+type folder struct {
+	t transform.Transformer
+}
+
+func newFolder() *folder {
+	return &folder{
+		t: transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), cases.Fold(), norm.NFC),
+	}
+}
+
+func (f *folder) fold(s string) string {
+	if isASCII(s) {
+		return strings.ToLower(s)
+	}
+
+	res, _, err := transform.String(f.t, s)
+	if err != nil {
+		return s
+	}
+	return res
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
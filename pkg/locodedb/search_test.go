@@ -0,0 +1,144 @@
+package locodedb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	search := func(query string, opts ...locodedb.SearchOption) map[string]locodedb.Record {
+		res := make(map[string]locodedb.Record)
+		for code, rec := range locodedb.Search(query, opts...) {
+			res[code] = rec
+		}
+		return res
+	}
+
+	t.Run("diacritics", func(t *testing.T) {
+		res := search("São Paulo")
+		require.Contains(t, res, "BRSAO")
+		require.Equal(t, res, search("SAO PAULO"))
+
+		require.Contains(t, search("München"), "DEMUC")
+	})
+
+	t.Run("subdivision", func(t *testing.T) {
+		res := search("moskva", locodedb.WithCountry("RU"))
+		require.Contains(t, res, "RUMOW")
+		for _, rec := range res {
+			require.Equal(t, "Russia", rec.Country)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		require.Contains(t, search("paris", locodedb.WithContinent(locodedb.ContinentEurope)), "FRPAR")
+		require.NotContains(t, search("paris", locodedb.WithContinent(locodedb.ContinentAsia)), "FRPAR")
+		require.NotContains(t, search("paris", locodedb.WithCountry("US")), "FRPAR")
+		require.Empty(t, search("paris", locodedb.WithCountry("ZZ")))
+		require.Empty(t, search("paris", locodedb.WithCountry("France")))
+	})
+
+	t.Run("order", func(t *testing.T) {
+		var prev string
+		for code := range locodedb.Search("san") {
+			require.Less(t, prev, code)
+			prev = code
+		}
+	})
+}
+
+func TestComplete(t *testing.T) {
+	complete := func(prefix string, n int, opts ...locodedb.SearchOption) []string {
+		var res []string
+		for code, rec := range locodedb.Complete(prefix, n, opts...) {
+			exp, err := locodedb.Get(code)
+			require.NoError(t, err)
+			require.Equal(t, exp, rec)
+			res = append(res, rec.Location)
+		}
+		return res
+	}
+
+	res := complete("San", 10)
+	require.Len(t, res, 10)
+	require.Equal(t, "San", res[0])
+	for i := range res {
+		require.Regexp(t, "(?i)^san", res[i])
+		if i > 0 {
+			require.LessOrEqual(t, strings.ToLower(res[i-1]), strings.ToLower(res[i]))
+		}
+	}
+	require.Contains(t, complete("Ams", 100), "Amsterdam")
+
+	res = complete("amsterdam", 5, locodedb.WithCountry("NL"))
+	require.Equal(t, []string{"Amsterdam"}, res)
+
+	require.Equal(t, []string{"Zurich"}, complete("zürich", 5, locodedb.WithCountry("CH")))
+	require.Empty(t, complete("Ams", 0))
+	require.Empty(t, complete("Amsterdamxyz", 10))
+	require.Empty(t, complete("Ams", 10, locodedb.WithCountry("ZZZ")))
+}
+
+func TestNativeNames(t *testing.T) {
+	db := openTestDB(t, "CH,Switzerland\nNO,Norway\n", `CHZRH,Zurich,1,ZH,Zürich (de),47.383335,8.533334,1234----,AI,,Zürich
+NOTOS,Tromso,1,55,Troms og Finnmark,69.683334,18.916666,1234----,AI,,Tromsø
+NOTRD,Trondheim,1,50,Trøndelag,63.433334,10.4,1234----,AI,,
+`)
+
+	rec, err := db.Get("NOTOS")
+	require.NoError(t, err)
+	require.Equal(t, "Tromso", rec.Location)
+	require.Equal(t, "Tromsø", rec.LocationNative)
+
+	rec, err = db.Get("NOTRD")
+	require.NoError(t, err)
+	require.Equal(t, "Trondheim", rec.LocationNative)
+
+	search := func(query string) []string {
+		var res []string
+		for code := range db.Search(query) {
+			res = append(res, code)
+		}
+		return res
+	}
+	require.Equal(t, []string{"NOTOS"}, search("tromsø"))
+	require.Equal(t, []string{"NOTOS"}, search("tromso"))
+	require.Equal(t, []string{"CHZRH"}, search("ZÜRICH"))
+
+	complete := func(prefix string) []string {
+		var res []string
+		for code := range db.Complete(prefix, 10) {
+			res = append(res, code)
+		}
+		return res
+	}
+	require.Equal(t, []string{"NOTOS"}, complete("Tromsø"))
+	require.Equal(t, []string{"NOTOS"}, complete("Tromso"))
+	require.Equal(t, []string{"NOTOS", "NOTRD"}, complete("Tro"))
+	require.Equal(t, []string{"CHZRH"}, complete("zur"))
+}
+
+func TestCompleteCountry(t *testing.T) {
+	db := openTestDB(t, testCountries, testLocodes+`RUSAN,Sankt-Anna,1,SPE,Sankt-Peterburg,59.88333,30.25
+FRSAN,Sannois,1,95,Val-d'Oise,48.966667,2.25
+`)
+
+	var codes []string
+	for code := range db.Complete("sa", 10, locodedb.WithCountry("RU")) {
+		codes = append(codes, code)
+	}
+	require.Equal(t, []string{"RUSAN", "RULED"}, codes)
+
+	codes = codes[:0]
+	for code := range db.Complete("sa", 10) {
+		codes = append(codes, code)
+	}
+	require.Equal(t, []string{"RUSAN", "RULED", "FRSAN"}, codes)
+
+	for code := range db.Complete("sa", 10, locodedb.WithCountry("ZZ")) {
+		t.Errorf("unexpected %s", code)
+	}
+}
@@ -0,0 +1,57 @@
+package locodedb
+
+import (
+	"fmt"
+)
+
+// PointSource shows where the Point of the record comes from.
+type PointSource uint8
+
+const (
+	// PointSourceUnknown is an undefined PointSource value (old data).
+	PointSourceUnknown PointSource = iota
+
+	// PointSourceUNLOCODE is the coordinates column of UN/LOCODE table.
+	PointSourceUNLOCODE
+
+	// PointSourceAirport is the location of the matching airport from
+	// OpenFlights database.
+	PointSourceAirport
+
+	// PointSourceOverride is the manual override of the coordinates.
+	PointSourceOverride
+
+	// PointSourceNone means that coordinates of the location are not
+	// known, Point is zero then.
+	PointSourceNone
+)
+
+// pointSourceNames are string representations of PointSource values.
+var pointSourceNames = [...]string{
+	PointSourceUnknown:  "",
+	PointSourceUNLOCODE: "unlocode",
+	PointSourceAirport:  "airport",
+	PointSourceOverride: "override",
+	PointSourceNone:     "none",
+}
+
+// String returns a string representation of the PointSource ("unlocode",
+// "airport", "override" or "none"). If the source is unknown, an empty string is
+// returned.
+func (s PointSource) String() string {
+	if int(s) >= len(pointSourceNames) {
+		return ""
+	}
+	return pointSourceNames[s]
+}
+
+// PointSourceFromString returns PointSource value corresponding to the passed
+// string representation. Empty string is PointSourceUnknown.
+func PointSourceFromString(str string) (PointSource, error) {
+	for i := range pointSourceNames {
+		if pointSourceNames[i] == str {
+			return PointSource(i), nil
+		}
+	}
+	return PointSourceUnknown, fmt.Errorf("%w: unknown point source %q", ErrInvalidString, str)
+}
@@ -0,0 +1,94 @@
+package locodedb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointSource(t *testing.T) {
+	for _, s := range []locodedb.PointSource{
+		locodedb.PointSourceUnknown,
+		locodedb.PointSourceUNLOCODE,
+		locodedb.PointSourceAirport,
+		locodedb.PointSourceOverride,
+		locodedb.PointSourceNone,
+	} {
+		res, err := locodedb.PointSourceFromString(s.String())
+		require.NoError(t, err)
+		require.Equal(t, s, res)
+	}
+	_, err := locodedb.PointSourceFromString("gps")
+	require.ErrorIs(t, err, locodedb.ErrInvalidString)
+
+	db := openTestDB(t, testCountries, `RULED,Sankt-Peterburg,1,SPE,Sankt-Peterburg,59.88333,30.25,1234----,AI,,,,,,,unlocode,1.852
+RUSVO,Sheremetyevo Apt/Moskva,1,MOS,Moskovskaya oblast',55.9726,37.4146,---4----,AI,,,,,,,airport,0.011132
+RUVKO,Vnukovo Apt/Moskva,1,MOS,Moskovskaya oblast',55.591531,37.261486,---4----,AI,,,,,,,airport,0.00000011132
+SESTO,Stockholm,1,AB,Stockholms län,59.333332,18.05,12345---,AI,,,,,,,override,
+`)
+	for code, exp := range map[string]struct {
+		source   locodedb.PointSource
+		accuracy float64
+	}{
+		"RULED": {locodedb.PointSourceUNLOCODE, 1.852},
+		"RUSVO": {locodedb.PointSourceAirport, 0.01113},
+		// Known accuracy isn't rounded to unknown.
+		"RUVKO": {locodedb.PointSourceAirport, 0.00001},
+		"SESTO": {locodedb.PointSourceOverride, 0},
+	} {
+		rec, err := db.Get(code)
+		require.NoError(t, err)
+		require.Equal(t, exp.source, rec.PointSource, code)
+		require.Equal(t, exp.accuracy, rec.Accuracy, code)
+	}
+
+	// Old tables have no provenance columns.
+	db = openTestDB(t, testCountries, testLocodes)
+	rec, err := db.Get("RUMOW")
+	require.NoError(t, err)
+	require.Equal(t, locodedb.PointSourceUnknown, rec.PointSource)
+	require.Zero(t, rec.Accuracy)
+
+	for _, s := range []string{"gps,1", "unlocode,-1", "unlocode,x"} {
+		_, err = locodedb.Open(strings.NewReader(testCountries), strings.NewReader("RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,,,,,,,"+s+"\n"))
+		require.Error(t, err, s)
+	}
+}
+
+func TestNoPoint(t *testing.T) {
+	db := openTestDB(t, testCountries, `RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,,,,,,,unlocode,1.852
+RUZZZ,Nowhere,1,MOS,Moskovskaya oblast',,,--3-----,RL,,,,,,,none,
+`)
+
+	rec, err := db.Get("RUZZZ")
+	require.NoError(t, err)
+	require.False(t, rec.HasPoint())
+	require.Zero(t, rec.Point)
+	require.EqualValues(t, locodedb.ContinentEurope, rec.Cont)
+
+	rec, err = db.Get("RUMOW")
+	require.NoError(t, err)
+	require.True(t, rec.HasPoint())
+
+	_, err = db.DistanceBetween("RUMOW", "RUZZZ")
+	require.ErrorIs(t, err, locodedb.ErrNoPoint)
+
+	code, _, _, err := db.Nearest(locodedb.Point{})
+	require.NoError(t, err)
+	require.Equal(t, "RUMOW", code)
+
+	for code := range db.WithinBBox(-90, -180, 90, 180) {
+		require.Equal(t, "RUMOW", code)
+	}
+
+	for _, row := range []string{
+		"RUMOW,Moskva,1,MOW,Moskva,,,1234----,AI,,,,,,,unlocode,\n",
+		"RUMOW,Moskva,1,MOW,Moskva,55.75,,1234----,AI,,,,,,,none,\n",
+		"RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,,,,,,,none,\n",
+	} {
+		_, err = locodedb.Open(strings.NewReader(testCountries), strings.NewReader(row))
+		require.Error(t, err, row)
+	}
+}
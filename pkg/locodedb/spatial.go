@@ -0,0 +1,333 @@
+package locodedb
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"math"
+	"slices"
+)
+
+// ErrInvalidPoint is returned when the geographic point has latitude or
+// longitude out of range.
+var ErrInvalidPoint = errors.New("invalid geographic point")
+
+// ErrNoPoint is returned when coordinates of the location are not known.
+var ErrNoPoint = errors.New("location has no coordinates")
+
+// Neighbor is a record found near some geographic point.
+type Neighbor struct {
+	// LOCODE is a LOCODE string without space separator.
+	LOCODE string
+	// Record is the location database record.
+	Record Record
+	// Distance is the great-circle distance to the point in kilometers.
+	Distance float64
+}
+
+// latBands is the number of one-degree latitude bands of the spatial index.
+const latBands = 180
+
+// spatialIndex groups all locations by one-degree latitude bands, locations of
+// every band are sorted by longitude.
+type spatialIndex struct {
+	// bands contains offsets of bands in entries, band i starts at bands[i]
+	// and ends at bands[i+1].
+	bands   [latBands + 1]uint32
+	entries []spatialEntry
+}
+
+type spatialEntry struct {
+	point Point
+	cc    countryCode
+	idx   uint32
+}
+
+// Nearest returns the location nearest to the given point from the default
+// DB. See [DB.Nearest] for details.
+func Nearest(p Point, opts ...SearchOption) (string, Record, float64, error) {
+	if err := initLocodeData(); err != nil {
+		return "", Record{}, 0, err
+	}
+	return defaultDB.Nearest(p, opts...)
+}
+
+// Nearest returns the LOCODE (without space separator) and the record of the
+// location nearest to the given point along with the great-circle distance to
+// it in kilometers. Options can be used to search among the locations
+// matching some filters only (like the nearest airport).
+//
+// Spatial index is built on the first call of Nearest or other spatial query.
+// Locations without coordinates (see [Record.HasPoint]) are not indexed, so
+// spatial queries never return them.
+func (db *DB) Nearest(p Point, opts ...SearchOption) (string, Record, float64, error) {
+	ns, err := db.KNearest(p, 1, opts...)
+	if err != nil {
+		return "", Record{}, 0, err
+	}
+	if len(ns) == 0 {
+		return "", Record{}, 0, ErrNotFound
+	}
+	return ns[0].LOCODE, ns[0].Record, ns[0].Distance, nil
+}
+
+// KNearest returns up to k locations nearest to the given point from the
+// default DB. See [DB.KNearest] for details.
+func KNearest(p Point, k int, opts ...SearchOption) ([]Neighbor, error) {
+	if err := initLocodeData(); err != nil {
+		return nil, err
+	}
+	return defaultDB.KNearest(p, k, opts...)
+}
+
+// KNearest returns up to k locations nearest to the given point ordered by
+// distance. Options can be used to search among the locations matching some
+// filters only.
+func (db *DB) KNearest(p Point, k int, opts ...SearchOption) ([]Neighbor, error) {
+	var (
+		idx = db.spatialData()
+		o   = newSearchOptions(opts)
+	)
+	if !p.isValid() {
+		return nil, ErrInvalidPoint
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	var found []spatialFound
+	// Start with a small circle and extend it until there are enough
+	// locations inside or the whole Earth is covered.
+	for radius := 16.0; ; radius *= 4 {
+		found = found[:0]
+		idx.within(p, radius, func(e *spatialEntry, dist float64) bool {
+			if o.matchEntry(db, e.cc, e.idx) {
+				found = append(found, spatialFound{e, dist})
+			}
+			return true
+		})
+		if len(found) >= k || radius >= math.Pi*earthRadius {
+			break
+		}
+	}
+
+	slices.SortFunc(found, func(a, b spatialFound) int {
+		if c := cmp.Compare(a.dist, b.dist); c != 0 {
+			return c
+		}
+		return compareSpatialEntries(a.entry, b.entry)
+	})
+
+	res := make([]Neighbor, 0, min(k, len(found)))
+	for i := range found[:min(k, len(found))] {
+		code, rec := db.record(found[i].entry.cc, found[i].entry.idx)
+		res = append(res, Neighbor{
+			LOCODE:   code,
+			Record:   rec,
+			Distance: found[i].dist,
+		})
+	}
+	return res, nil
+}
+
+// WithinRadius returns an iterator over all locations of the default DB
+// within the radius from the center. If the DB can't be unpacked, the sequence
+// is empty. See [DB.WithinRadius] for details.
+func WithinRadius(center Point, radius float64, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.WithinRadius(center, radius, opts...)(yield)
+	}
+}
+
+// WithinRadius returns an iterator over all locations with great-circle
+// distance to the center not exceeding radius (in kilometers). Keys are
+// LOCODE strings without space separator, locations are passed in no
+// particular order. If the center is invalid, the sequence is empty.
+func (db *DB) WithinRadius(center Point, radius float64, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if !center.isValid() || !(radius >= 0) {
+			return
+		}
+
+		o := newSearchOptions(opts)
+		db.spatialData().within(center, radius, func(e *spatialEntry, _ float64) bool {
+			return !o.matchEntry(db, e.cc, e.idx) || yield(db.record(e.cc, e.idx))
+		})
+	}
+}
+
+// WithinBBox returns an iterator over all locations of the default DB inside
+// the bounding box. If the DB can't be unpacked, the sequence is empty. See
+// [DB.WithinBBox] for details.
+func WithinBBox(minLat, minLng, maxLat, maxLng float64, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.WithinBBox(minLat, minLng, maxLat, maxLng, opts...)(yield)
+	}
+}
+
+// WithinBBox returns an iterator over all locations inside the bounding box.
+// The box crosses the antimeridian if minLng is greater than maxLng. Keys are
+// LOCODE strings without space separator, locations are passed in no
+// particular order. If the box is invalid, the sequence is empty.
+func (db *DB) WithinBBox(minLat, minLng, maxLat, maxLng float64, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if !(minLat <= maxLat) ||
+			!(Point{Latitude: float32(minLat), Longitude: float32(minLng)}).isValid() ||
+			!(Point{Latitude: float32(maxLat), Longitude: float32(maxLng)}).isValid() {
+			return
+		}
+
+		o := newSearchOptions(opts)
+		db.spatialData().bbox(minLat, minLng, maxLat, maxLng, func(e *spatialEntry) bool {
+			return !o.matchEntry(db, e.cc, e.idx) || yield(db.record(e.cc, e.idx))
+		})
+	}
+}
+
+type spatialFound struct {
+	entry *spatialEntry
+	dist  float64
+}
+
+func (db *DB) spatialData() *spatialIndex {
+	db.spatialOnce.Do(func() {
+		db.spatial = newSpatialIndex(db.countries)
+	})
+	return db.spatial
+}
+
+func newSpatialIndex(mc map[countryCode]countryData) *spatialIndex {
+	var (
+		idx spatialIndex
+		num int
+	)
+	for _, cd := range mc {
+		num += len(cd.locodes)
+	}
+
+	idx.entries = make([]spatialEntry, 0, num)
+	for cc, cd := range mc {
+		for i := range cd.locodes {
+			if cd.locodes[i].pointSource == PointSourceNone {
+				continue
+			}
+			idx.entries = append(idx.entries, spatialEntry{
+				point: cd.locodes[i].point,
+				cc:    cc,
+				idx:   uint32(i),
+			})
+		}
+	}
+
+	slices.SortFunc(idx.entries, func(a, b spatialEntry) int {
+		if c := cmp.Compare(latBand(float64(a.point.Latitude)), latBand(float64(b.point.Latitude))); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.point.Longitude, b.point.Longitude); c != 0 {
+			return c
+		}
+		return compareSpatialEntries(&a, &b)
+	})
+
+	var band int
+	for i := range idx.entries {
+		for b := latBand(float64(idx.entries[i].point.Latitude)); band < b; band++ {
+			idx.bands[band+1] = uint32(i)
+		}
+	}
+	for ; band < latBands; band++ {
+		idx.bands[band+1] = uint32(len(idx.entries))
+	}
+
+	return &idx
+}
+
+func latBand(lat float64) int {
+	return min(max(int(math.Floor(lat+90)), 0), latBands-1)
+}
+
+func compareSpatialEntries(a, b *spatialEntry) int {
+	if c := compareCountryCodes(a.cc, b.cc); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.idx, b.idx)
+}
+
+// within passes all entries with distance to the center not exceeding radius
+// (in kilometers) to f until it returns false.
+func (idx *spatialIndex) within(center Point, radius float64, f func(*spatialEntry, float64) bool) {
+	var (
+		lat = float64(center.Latitude)
+		lng = float64(center.Longitude)
+		r   = radToDeg(radius / earthRadius)
+
+		minLat = lat - r
+		maxLat = lat + r
+		minLng = -180.0
+		maxLng = 180.0
+	)
+	// The circle can't be bounded by longitude if it covers a pole.
+	if minLat > -90 && maxLat < 90 {
+		dLng := radToDeg(math.Asin(math.Sin(degToRad(r)) / math.Cos(degToRad(lat))))
+		if dLng < 180 {
+			minLng, maxLng = normalizeLongitude(lng-dLng), normalizeLongitude(lng+dLng)
+		}
+	}
+
+	idx.bbox(minLat, minLng, maxLat, maxLng, func(e *spatialEntry) bool {
+		if d := center.Distance(e.point); d <= radius {
+			return f(e, d)
+		}
+		return true
+	})
+}
+
+// bbox passes all entries inside the bounding box to f until it returns
+// false. Box crosses the antimeridian if minLng is greater than maxLng.
+func (idx *spatialIndex) bbox(minLat, minLng, maxLat, maxLng float64, f func(*spatialEntry) bool) {
+	for band := latBand(minLat); band <= latBand(maxLat); band++ {
+		entries := idx.entries[idx.bands[band]:idx.bands[band+1]]
+		if minLng <= maxLng {
+			if !scanBand(entries, minLat, minLng, maxLat, maxLng, f) {
+				return
+			}
+			continue
+		}
+		if !scanBand(entries, minLat, minLng, maxLat, 180, f) ||
+			!scanBand(entries, minLat, -180, maxLat, maxLng, f) {
+			return
+		}
+	}
+}
+
+func scanBand(entries []spatialEntry, minLat, minLng, maxLat, maxLng float64, f func(*spatialEntry) bool) bool {
+	i, _ := slices.BinarySearchFunc(entries, minLng, func(e spatialEntry, lng float64) int {
+		return cmp.Compare(float64(e.point.Longitude), lng)
+	})
+	for ; i < len(entries) && float64(entries[i].point.Longitude) <= maxLng; i++ {
+		if lat := float64(entries[i].point.Latitude); lat < minLat || lat > maxLat {
+			continue
+		}
+		if !f(&entries[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeLongitude brings longitude to [-180, 180] range.
+func normalizeLongitude(lng float64) float64 {
+	if lng < -180 {
+		return lng + 360
+	}
+	if lng > 180 {
+		return lng - 360
+	}
+	return lng
+}
@@ -0,0 +1,138 @@
+package locodedb_test
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+// haversine is an independent great-circle distance implementation.
+func haversine(a, b locodedb.Point) float64 {
+	const rad = math.Pi / 180
+	var (
+		dLat = (float64(b.Latitude) - float64(a.Latitude)) * rad
+		dLng = (float64(b.Longitude) - float64(a.Longitude)) * rad
+		h    = math.Pow(math.Sin(dLat/2), 2) +
+			math.Cos(float64(a.Latitude)*rad)*math.Cos(float64(b.Latitude)*rad)*math.Pow(math.Sin(dLng/2), 2)
+	)
+	return 2 * 6371.0088 * math.Asin(math.Sqrt(h))
+}
+
+func bruteNearest(p locodedb.Point, k int) []float64 {
+	var dists []float64
+	for _, rec := range locodedb.All() {
+		dists = append(dists, haversine(p, rec.Point))
+	}
+	slices.SortFunc(dists, cmp.Compare)
+	return dists[:k]
+}
+
+func TestNearest(t *testing.T) {
+	mow, err := locodedb.Get("RU MOW")
+	require.NoError(t, err)
+
+	code, rec, dist, err := locodedb.Nearest(mow.Point)
+	require.NoError(t, err)
+	require.Zero(t, dist)
+	require.Equal(t, mow.Point, rec.Point)
+	if code == "RUMOW" {
+		require.Equal(t, mow, rec)
+	}
+
+	_, _, _, err = locodedb.Nearest(locodedb.Point{Latitude: 91})
+	require.ErrorIs(t, err, locodedb.ErrInvalidPoint)
+
+	for _, p := range []locodedb.Point{
+		mow.Point,
+		{Latitude: -17.5, Longitude: 179.99},  // Fiji, antimeridian
+		{Latitude: 64.7, Longitude: -179.5},   // Chukotka, antimeridian
+		{Latitude: 89.9, Longitude: 0},        // North Pole
+		{Latitude: -50, Longitude: -120},      // Pacific Ocean
+		{Latitude: 59.33, Longitude: 18.0686}, // Stockholm
+	} {
+		ns, err := locodedb.KNearest(p, 10)
+		require.NoError(t, err)
+		require.Len(t, ns, 10)
+
+		exp := bruteNearest(p, 10)
+		for i := range ns {
+			require.InDelta(t, exp[i], ns[i].Distance, 1e-6)
+			require.InDelta(t, haversine(p, ns[i].Record.Point), ns[i].Distance, 1e-6)
+
+			rec, err := locodedb.Get(ns[i].LOCODE)
+			require.NoError(t, err)
+			require.Equal(t, rec, ns[i].Record)
+		}
+	}
+}
+
+func TestWithin(t *testing.T) {
+	collect := func(seq func(func(string, locodedb.Record) bool)) []string {
+		var res []string
+		for code := range seq {
+			res = append(res, code)
+		}
+		slices.Sort(res)
+		return res
+	}
+	filter := func(f func(locodedb.Record) bool) []string {
+		var res []string
+		for code, rec := range locodedb.All() {
+			if f(rec) {
+				res = append(res, code)
+			}
+		}
+		return res
+	}
+
+	t.Run("radius", func(t *testing.T) {
+		for _, tc := range []struct {
+			center locodedb.Point
+			radius float64
+		}{
+			{locodedb.Point{Latitude: 55.75, Longitude: 37.61}, 50},
+			{locodedb.Point{Latitude: -17.5, Longitude: 179.9}, 300},
+			{locodedb.Point{Latitude: 80, Longitude: 20}, 1000},
+			{locodedb.Point{Latitude: 0, Longitude: 0}, 0},
+		} {
+			res := collect(locodedb.WithinRadius(tc.center, tc.radius))
+			exp := filter(func(rec locodedb.Record) bool {
+				return haversine(tc.center, rec.Point) <= tc.radius
+			})
+			require.Equal(t, exp, res)
+		}
+		require.Empty(t, collect(locodedb.WithinRadius(locodedb.Point{Latitude: 100}, 100)))
+		require.Empty(t, collect(locodedb.WithinRadius(locodedb.Point{}, -1)))
+	})
+
+	t.Run("bbox", func(t *testing.T) {
+		inside := func(minLat, minLng, maxLat, maxLng float64) func(locodedb.Record) bool {
+			return func(rec locodedb.Record) bool {
+				lat, lng := float64(rec.Point.Latitude), float64(rec.Point.Longitude)
+				if lat < minLat || lat > maxLat {
+					return false
+				}
+				if minLng <= maxLng {
+					return lng >= minLng && lng <= maxLng
+				}
+				return lng >= minLng || lng <= maxLng
+			}
+		}
+		for _, box := range [][4]float64{
+			{55, 37, 56, 38.5},
+			{-20, 175, -10, -175}, // crosses the antimeridian
+			{-90, -180, 90, 180},
+		} {
+			res := collect(locodedb.WithinBBox(box[0], box[1], box[2], box[3]))
+			exp := filter(inside(box[0], box[1], box[2], box[3]))
+			require.NotEmpty(t, exp)
+			require.Equal(t, exp, res)
+		}
+		require.Empty(t, collect(locodedb.WithinBBox(56, 37, 55, 38)))
+		require.Empty(t, collect(locodedb.WithinBBox(55, 37, 56, 190)))
+	})
+}
@@ -0,0 +1,120 @@
+package locodedb
+
+import (
+	"fmt"
+)
+
+// Status is a UN/LOCODE entry status, it shows who approved the entry and
+// how reliable it is.
+type Status uint8
+
+const (
+	// StatusUnknown is an undefined Status value.
+	StatusUnknown Status = iota
+
+	// StatusApprovedByGovernment is "AA", approved by competent national
+	// government agency.
+	StatusApprovedByGovernment
+
+	// StatusApprovedByCustoms is "AC", approved by Customs Authority.
+	StatusApprovedByCustoms
+
+	// StatusApprovedByFacilitationBody is "AF", approved by national
+	// facilitation body.
+	StatusApprovedByFacilitationBody
+
+	// StatusAdoptedByInternationalOrg is "AI", code adopted by international
+	// organisation (IATA or ECLAC).
+	StatusAdoptedByInternationalOrg
+
+	// StatusApprovedFunctionsNotVerified is "AQ", entry approved, functions
+	// not verified.
+	StatusApprovedFunctionsNotVerified
+
+	// StatusApprovedByStandardisationBody is "AS", approved by national
+	// standardisation body.
+	StatusApprovedByStandardisationBody
+
+	// StatusRecognised is "RL", recognised location: existence and
+	// representation of location name confirmed by check against nominated
+	// gazetteer or other reference work.
+	StatusRecognised
+
+	// StatusRequestedNationally is "RN", request from credible national
+	// sources for locations in their own country.
+	StatusRequestedNationally
+
+	// StatusRequested is "RQ", request under consideration.
+	StatusRequested
+
+	// StatusRejected is "RR", request rejected.
+	StatusRejected
+
+	// StatusNotVerified is "QQ", original entry not verified since date
+	// indicated.
+	StatusNotVerified
+
+	// StatusUserRequested is "UR", entry included on user's request, not
+	// officially approved.
+	StatusUserRequested
+
+	// StatusToBeRemoved is "XX", entry that will be removed from the next
+	// issue of UN/LOCODE.
+	StatusToBeRemoved
+)
+
+// statusCodes are UN/LOCODE codes of Status values.
+var statusCodes = [...]string{
+	StatusUnknown:                       "",
+	StatusApprovedByGovernment:          "AA",
+	StatusApprovedByCustoms:             "AC",
+	StatusApprovedByFacilitationBody:    "AF",
+	StatusAdoptedByInternationalOrg:     "AI",
+	StatusApprovedFunctionsNotVerified:  "AQ",
+	StatusApprovedByStandardisationBody: "AS",
+	StatusRecognised:                    "RL",
+	StatusRequestedNationally:           "RN",
+	StatusRequested:                     "RQ",
+	StatusRejected:                      "RR",
+	StatusNotVerified:                   "QQ",
+	StatusUserRequested:                 "UR",
+	StatusToBeRemoved:                   "XX",
+}
+
+// String returns a 2-character UN/LOCODE code of the Status. If the Status is
+// unknown, an empty string is returned.
+func (s Status) String() string {
+	if int(s) >= len(statusCodes) {
+		return ""
+	}
+	return statusCodes[s]
+}
+
+// StatusFromString returns Status value corresponding to the passed
+// 2-character UN/LOCODE code. Empty string is StatusUnknown.
+func StatusFromString(str string) (Status, error) {
+	for i := range statusCodes {
+		if statusCodes[i] == str {
+			return Status(i), nil
+		}
+	}
+	return StatusUnknown, fmt.Errorf("%w: unknown status %q", ErrInvalidString, str)
+}
+
+// IsApproved checks whether the entry is approved by some authority (all "A*"
+// statuses).
+func (s Status) IsApproved() bool {
+	return s >= StatusApprovedByGovernment && s <= StatusApprovedByStandardisationBody
+}
+
+// IsRequested checks whether the entry is a request under consideration
+// ("RN" and "RQ" statuses).
+func (s Status) IsRequested() bool {
+	return s == StatusRequestedNationally || s == StatusRequested
+}
+
+// IsMarkedForRemoval checks whether the entry will be removed from the next
+// issue of UN/LOCODE ("XX" status).
+func (s Status) IsMarkedForRemoval() bool {
+	return s == StatusToBeRemoved
+}
@@ -0,0 +1,57 @@
+package locodedb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	for _, tc := range []struct {
+		code                           string
+		approved, requested, toRemoval bool
+	}{
+		{"", false, false, false},
+		{"AA", true, false, false},
+		{"AC", true, false, false},
+		{"AF", true, false, false},
+		{"AI", true, false, false},
+		{"AQ", true, false, false},
+		{"AS", true, false, false},
+		{"RL", false, false, false},
+		{"RN", false, true, false},
+		{"RQ", false, true, false},
+		{"RR", false, false, false},
+		{"QQ", false, false, false},
+		{"UR", false, false, false},
+		{"XX", false, false, true},
+	} {
+		s, err := locodedb.StatusFromString(tc.code)
+		require.NoError(t, err, tc.code)
+		require.Equal(t, tc.code, s.String())
+		require.Equal(t, tc.approved, s.IsApproved(), tc.code)
+		require.Equal(t, tc.requested, s.IsRequested(), tc.code)
+		require.Equal(t, tc.toRemoval, s.IsMarkedForRemoval(), tc.code)
+	}
+
+	_, err := locodedb.StatusFromString("ZZ")
+	require.ErrorIs(t, err, locodedb.ErrInvalidString)
+
+	db := openTestDB(t, testCountries, `RULED,Sankt-Peterburg,1,SPE,Sankt-Peterburg,59.88333,30.25,1234----,AI
+RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,RQ
+`)
+	rec, err := db.Get("RUMOW")
+	require.NoError(t, err)
+	require.Equal(t, locodedb.StatusRequested, rec.Status)
+
+	// Old tables have no status column.
+	db = openTestDB(t, testCountries, testLocodes)
+	rec, err = db.Get("RUMOW")
+	require.NoError(t, err)
+	require.Equal(t, locodedb.StatusUnknown, rec.Status)
+
+	_, err = locodedb.Open(strings.NewReader(testCountries), strings.NewReader("RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,ZZ\n"))
+	require.Error(t, err)
+}
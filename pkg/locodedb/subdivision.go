@@ -0,0 +1,126 @@
+package locodedb
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Subdivision represents an administrative division of a country.
+type Subdivision struct {
+	// Code is ISO 3166-2 subdivision code without country prefix.
+	Code string
+	// Name is a full subdivision name, empty if unknown.
+	Name string
+	// Type is a subdivision type (like "Region" or "State"), empty if
+	// unknown.
+	Type string
+	// Locations is the number of LOCODEs in the subdivision.
+	Locations int
+}
+
+// GetSubdivision returns a subdivision for a given ISO 3166 alpha-2 country
+// code and subdivision code (like "RU" and "MOW") from the default DB.
+func GetSubdivision(country, code string) (Subdivision, error) {
+	if err := initLocodeData(); err != nil {
+		return Subdivision{}, err
+	}
+	return defaultDB.GetSubdivision(country, code)
+}
+
+// GetSubdivision returns a subdivision for a given ISO 3166 alpha-2 country
+// code and subdivision code (like "RU" and "MOW").
+func (db *DB) GetSubdivision(country, code string) (Subdivision, error) {
+	subDivs, err := db.countrySubdivisions(country)
+	if err != nil {
+		return Subdivision{}, err
+	}
+
+	n, ok := slices.BinarySearchFunc(subDivs, code, func(sd Subdivision, s string) int {
+		return cmp.Compare(sd.Code, s)
+	})
+	if !ok {
+		return Subdivision{}, ErrNotFound
+	}
+
+	return subDivs[n], nil
+}
+
+// Subdivisions returns an iterator over all subdivisions of a country from the
+// default DB. If the DB can't be unpacked, the sequence is empty.
+func Subdivisions(country string) iter.Seq[Subdivision] {
+	return func(yield func(Subdivision) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.Subdivisions(country)(yield)
+	}
+}
+
+// Subdivisions returns an iterator over all subdivisions of a country ordered
+// by code. If the country is not found, the sequence is empty.
+//
+// Subdivision index is built on the first call of Subdivisions or
+// GetSubdivision.
+func (db *DB) Subdivisions(country string) iter.Seq[Subdivision] {
+	return func(yield func(Subdivision) bool) {
+		subDivs, err := db.countrySubdivisions(country)
+		if err != nil {
+			return
+		}
+
+		for i := range subDivs {
+			if !yield(subDivs[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (db *DB) countrySubdivisions(country string) ([]Subdivision, error) {
+	cc, err := countryCodeFromString(country)
+	if err != nil {
+		return nil, ErrInvalidString
+	}
+
+	if _, ok := db.countries[*cc]; !ok {
+		return nil, ErrNotFound
+	}
+
+	db.subDivsOnce.Do(func() {
+		db.subDivs = db.collectSubdivisions()
+	})
+
+	return db.subDivs[*cc], nil
+}
+
+func (db *DB) collectSubdivisions() map[countryCode][]Subdivision {
+	m := make(map[countryCode][]Subdivision, len(db.countries))
+
+	for cc, cd := range db.countries {
+		var subDivs []Subdivision
+		for i := range cd.locodes {
+			code := db.divCodeFromCSV(&cd.locodes[i])
+			if code == "" {
+				continue
+			}
+
+			n, ok := slices.BinarySearchFunc(subDivs, code, func(sd Subdivision, s string) int {
+				return cmp.Compare(sd.Code, s)
+			})
+			if !ok {
+				subDivs = slices.Insert(subDivs, n, Subdivision{
+					Code: code,
+					Name: db.divNameFromCSV(&cd.locodes[i]),
+					Type: db.subDivTypes[cd.locodes[i].subDivType],
+				})
+			}
+			subDivs[n].Locations++
+		}
+		if len(subDivs) != 0 {
+			m[cc] = slices.Clip(subDivs)
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,65 @@
+package locodedb
+
+import (
+	"iter"
+	"math"
+	"time"
+)
+
+// monthFromTime converts time to the number of months since the year 0 used
+// to store the last change date. Zero value is reserved for unknown dates.
+func monthFromTime(t time.Time) uint16 {
+	y, m, _ := t.Date()
+	if y <= 0 || y*12+11 > math.MaxUint16 {
+		return 0
+	}
+	return uint16(y*12 + int(m) - 1)
+}
+
+// timeFromMonth converts the number of months since the year 0 to the first
+// day of the month.
+func timeFromMonth(m uint16) time.Time {
+	if m == 0 {
+		return time.Time{}
+	}
+	return time.Date(int(m/12), time.Month(m%12+1), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ChangedSince returns an iterator over records of the default DB changed
+// since the given time. If the DB can't be unpacked, the sequence is empty.
+// See [DB.ChangedSince] for details.
+func ChangedSince(t time.Time, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		if initLocodeData() != nil {
+			return
+		}
+		defaultDB.ChangedSince(t, opts...)(yield)
+	}
+}
+
+// ChangedSince returns an iterator over records changed in the month of t or
+// later (dates have month precision). Records with unknown date never match.
+// Records are ordered by LOCODE, keys are LOCODE strings without space
+// separator.
+func (db *DB) ChangedSince(t time.Time, opts ...SearchOption) iter.Seq2[string, Record] {
+	return func(yield func(string, Record) bool) {
+		var (
+			o       = newSearchOptions(opts)
+			y, m, _ = t.UTC().Date()
+			// since is not limited by uint16 range to handle any t.
+			since = y*12 + int(m) - 1
+		)
+		for _, cc := range o.countries(db) {
+			cd := db.countries[cc]
+			for i := range cd.locodes {
+				c := &cd.locodes[i]
+				if c.updated == 0 || int(c.updated) < since || !o.match(cc, c) {
+					continue
+				}
+				if !yield(string(cc[:])+db.codeFromCSV(c), db.recordFromCSV(&cd, c)) {
+					return
+				}
+			}
+		}
+	}
+}
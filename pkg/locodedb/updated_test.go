@@ -0,0 +1,44 @@
+package locodedb_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/locode-db/pkg/locodedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedSince(t *testing.T) {
+	db := openTestDB(t, testCountries, `FRPAR,Paris,1,75,Paris,48.86667,2.333333,1234----,AI,,,2017-07
+RULED,Sankt-Peterburg,1,SPE,Sankt-Peterburg,59.88333,30.25,1234----,AI,,,2020-03
+RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,---4----,AI,,,2023-01
+SESTO,Stockholm,1,AB,Stockholms län [SE-01],59.333332,18.05,12345---,AI,,,
+`)
+
+	rec, err := db.Get("RULED")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC), rec.Updated)
+
+	rec, err = db.Get("SESTO")
+	require.NoError(t, err)
+	require.True(t, rec.Updated.IsZero())
+
+	changed := func(t time.Time, opts ...locodedb.SearchOption) []string {
+		var res []string
+		for code := range db.ChangedSince(t, opts...) {
+			res = append(res, code)
+		}
+		return res
+	}
+
+	require.Equal(t, []string{"FRPAR", "RULED", "RUMOW"}, changed(time.Time{}))
+	require.Equal(t, []string{"RULED", "RUMOW"}, changed(time.Date(2020, time.March, 31, 23, 0, 0, 0, time.UTC)))
+	require.Equal(t, []string{"RUMOW"}, changed(time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, []string{"RULED"}, changed(time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC), locodedb.WithCountry("RU"), locodedb.WithFunctions(locodedb.FunctionPort)))
+	require.Empty(t, changed(time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)))
+	require.Empty(t, changed(time.Date(9999, time.January, 1, 0, 0, 0, 0, time.UTC)))
+
+	_, err = locodedb.Open(strings.NewReader(testCountries), strings.NewReader("RUMOW,Moskva,1,MOW,Moskva,55.75,37.616665,1234----,AI,,,2301\n"))
+	require.Error(t, err)
+}